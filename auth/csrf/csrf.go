@@ -0,0 +1,83 @@
+// Package csrf binds the kyber identity of a peer to an HTTP session, to
+// protect the HTTP endpoints of a service against cross-site request forgery.
+// A token is the Schnorr signature of the session identifier by the private
+// key of the peer, which a cross-site attacker cannot produce.
+//
+// The tokens are sent in the X-Kyber-Auth header, encoded in base64.
+package csrf
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/sign/schnorr"
+)
+
+// Suite represents the set of functionalities needed by the package csrf.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+// Header is the HTTP header carrying the token.
+const Header = "X-Kyber-Auth"
+
+// domain separates the signatures of the tokens from the other uses of the
+// key.
+var domain = []byte("kyber csrf token")
+
+var errToken = errors.New("csrf: invalid token")
+
+// GenerateToken returns the token of the session sessionID for the peer of
+// private key priv.
+func GenerateToken(suite Suite, priv kyber.Scalar, sessionID []byte) ([]byte, error) {
+	return schnorr.Sign(suite, priv, message(sessionID))
+}
+
+// VerifyToken checks that token was generated for the session sessionID by
+// the peer of public key pub.
+func VerifyToken(suite Suite, pub kyber.Point, sessionID, token []byte) error {
+	if err := schnorr.Verify(suite, pub, message(sessionID), token); err != nil {
+		return errToken
+	}
+	return nil
+}
+
+func message(sessionID []byte) []byte {
+	return append(append([]byte{}, domain...), sessionID...)
+}
+
+// KyberAuthMiddleware returns a middleware that rejects the requests whose
+// X-Kyber-Auth header is not the token of their session by one of the known
+// peers, with the status 403 Forbidden. The session identifier of a request
+// is given by the function session, which returns nil if the request has no
+// session.
+func KyberAuthMiddleware(suite Suite, knownPeers []kyber.Point, session func(*http.Request) []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorized(suite, knownPeers, session(r), r.Header.Get(Header)) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func authorized(suite Suite, knownPeers []kyber.Point, sessionID []byte, header string) bool {
+	if sessionID == nil || header == "" {
+		return false
+	}
+	token, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return false
+	}
+	for _, pub := range knownPeers {
+		if VerifyToken(suite, pub, sessionID, token) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package csrf
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/key"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func TestToken(t *testing.T) {
+	kp := key.NewKeyPair(suite)
+	session := []byte("session 1")
+	token, err := GenerateToken(suite, kp.Private, session)
+	require.Nil(t, err)
+	require.Nil(t, VerifyToken(suite, kp.Public, session, token))
+	require.Equal(t, errToken, VerifyToken(suite, kp.Public, []byte("session 2"), token))
+	require.Equal(t, errToken, VerifyToken(suite, key.NewKeyPair(suite).Public, session, token))
+	require.Equal(t, errToken, VerifyToken(suite, kp.Public, session, token[1:]))
+}
+
+const cookie = "session"
+
+func sessionFromCookie(r *http.Request) []byte {
+	c, err := r.Cookie(cookie)
+	if err != nil {
+		return nil
+	}
+	return []byte(c.Value)
+}
+
+func TestKyberAuthMiddleware(t *testing.T) {
+	peer, other := key.NewKeyPair(suite), key.NewKeyPair(suite)
+	handler := KyberAuthMiddleware(suite, []kyber.Point{key.NewKeyPair(suite).Public, peer.Public}, sessionFromCookie)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "ok")
+		}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	do := func(priv kyber.Scalar, tokenSession, cookieSession string) int {
+		req, err := http.NewRequest("POST", server.URL, nil)
+		require.Nil(t, err)
+		if cookieSession != "" {
+			req.AddCookie(&http.Cookie{Name: cookie, Value: cookieSession})
+		}
+		if priv != nil {
+			token, err := GenerateToken(suite, priv, []byte(tokenSession))
+			require.Nil(t, err)
+			req.Header.Set(Header, base64.StdEncoding.EncodeToString(token))
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, do(peer.Private, "abc", "abc"))
+	// unsigned, signed by an unknown peer, for another session, or without
+	// a session
+	require.Equal(t, http.StatusForbidden, do(nil, "", "abc"))
+	require.Equal(t, http.StatusForbidden, do(other.Private, "abc", "abc"))
+	require.Equal(t, http.StatusForbidden, do(peer.Private, "abc", "def"))
+	require.Equal(t, http.StatusForbidden, do(peer.Private, "abc", ""))
+
+	req, err := http.NewRequest("POST", server.URL, nil)
+	require.Nil(t, err)
+	req.AddCookie(&http.Cookie{Name: cookie, Value: "abc"})
+	req.Header.Set(Header, "not base64!")
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,452 @@
+// Package opaque implements the OPAQUE asymmetric password-authenticated key
+// exchange, following the structure of draft-irtf-cfrg-opaque.
+//
+// The client never sends its password to the server. Instead, it obtains
+// the output of an oblivious PRF (2HashDH) keyed by the server and evaluated
+// on the password, from which it derives its long-term key pair and the keys
+// protecting its envelope. During login, the same OPRF evaluation lets the
+// client recover its private key, and the parties run a 3DH key exchange
+// that authenticates both of them and yields a shared session key.
+//
+// Registration:
+//
+//	client: state, req, err := ClientRegistration(suite, password)
+//	server: resp, err := ServerRegistration(suite, server, id, req)
+//	client: record, err := state.Upload(resp)
+//	server: stores record for id
+//
+// Login:
+//
+//	client: state, ke1, err := ClientLogin(suite, password)
+//	server: sstate, ke2, err := ServerLogin(suite, server, id, record, ke1)
+//	client: ke3, key, err := state.Finalize(ke2)
+//	server: key, err := sstate.Finish(ke3)
+//
+// The server must keep its private key and OPRF seed across restarts, and
+// restore them with NewServer, since the records of the clients depend on
+// them. For an unknown id, it runs ServerLogin with a nil record, which
+// answers with a fake record so that the client cannot tell whether the id
+// is registered.
+//
+// This implementation works on kyber types and is not wire compatible with
+// the draft. In particular, the credential response is not masked, so a
+// passive observer of a login learns the envelope of the client.
+package opaque
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/hashtopoint"
+	"github.com/dedis/kyber/util/kdf"
+	"github.com/dedis/kyber/util/random"
+)
+
+// Suite represents the set of functionalities needed by the package opaque.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// nonceLen is the size in bytes of the nonces and of the OPRF seed used by
+// the protocol.
+const nonceLen = 32
+
+var errInvalidPoint = errors.New("opaque: invalid blinded password")
+
+// Server holds the long-term key pair of the server and the seed from which
+// the OPRF key of each client is derived.
+type Server struct {
+	Private kyber.Scalar
+	Public  kyber.Point
+	seed    []byte
+}
+
+// ServerSetup creates a new server key pair and OPRF seed.
+func ServerSetup(suite Suite) *Server {
+	seed := make([]byte, nonceLen)
+	random.Bytes(seed, suite.RandomStream())
+	priv := suite.Scalar().Pick(suite.RandomStream())
+	return &Server{
+		Private: priv,
+		Public:  suite.Point().Mul(priv, nil),
+		seed:    seed,
+	}
+}
+
+// NewServer restores a server from its private key and the OPRF seed
+// returned by Seed.
+func NewServer(suite Suite, private kyber.Scalar, seed []byte) (*Server, error) {
+	if len(seed) != nonceLen {
+		return nil, errors.New("opaque: OPRF seed must be 32 bytes long")
+	}
+	return &Server{
+		Private: private,
+		Public:  suite.Point().Mul(private, nil),
+		seed:    append([]byte{}, seed...),
+	}, nil
+}
+
+// Seed returns the OPRF seed of the server, which must be stored as secretly
+// as the private key.
+func (s *Server) Seed() []byte {
+	return append([]byte{}, s.seed...)
+}
+
+// oprfKey derives the OPRF key of the client with the given credential id.
+func (s *Server) oprfKey(suite Suite, id []byte) kyber.Scalar {
+	xof := suite.XOF([]byte("OPAQUE-OPRFKey"))
+	_, _ = xof.Write(s.seed)
+	_, _ = xof.Write(id)
+	return suite.Scalar().Pick(xof)
+}
+
+// fakeRecord returns the record with which the server answers a login for an
+// unknown credential id. The envelope nonce and tag are derived from the seed
+// and the id, so that repeated logins see the same ones as for a registered
+// client, and the client public key is random.
+func (s *Server) fakeRecord(suite Suite, id []byte) *Record {
+	xof := suite.XOF([]byte("OPAQUE-FakeRecord"))
+	_, _ = xof.Write(s.seed)
+	_, _ = xof.Write(id)
+	record := &Record{
+		ClientPublic: suite.Point().Pick(suite.RandomStream()),
+		Nonce:        make([]byte, nonceLen),
+		AuthTag:      make([]byte, suite.Hash().Size()),
+	}
+	random.Bytes(record.Nonce, xof)
+	random.Bytes(record.AuthTag, xof)
+	return record
+}
+
+// evaluate evaluates the OPRF of the client with credential id on the
+// blinded password alpha. It rejects the identity and the points with a
+// small-order component, whose multiple would leak the OPRF key modulo the
+// cofactor.
+func (s *Server) evaluate(suite Suite, id []byte, alpha kyber.Point) (kyber.Point, error) {
+	if alpha == nil || alpha.Equal(suite.Point().Null()) || !inSubgroup(suite, alpha) {
+		return nil, errInvalidPoint
+	}
+	return suite.Point().Mul(s.oprfKey(suite, id), alpha), nil
+}
+
+// inSubgroup tells whether P is in the prime-order subgroup. The scalar -1 is
+// ℓ-1 for the prime order ℓ, so [ℓ-1]P + P is the identity iff P has no
+// small-order component.
+func inSubgroup(suite Suite, P kyber.Point) bool {
+	Q := suite.Point().Mul(suite.Scalar().SetInt64(-1), P)
+	return Q.Add(Q, P).Equal(suite.Point().Null())
+}
+
+// RegistrationRequest is sent by the client to start its registration.
+type RegistrationRequest struct {
+	Alpha kyber.Point // blinded password
+}
+
+// RegistrationResponse is the answer of the server to a RegistrationRequest.
+type RegistrationResponse struct {
+	Beta         kyber.Point // evaluated blinded password
+	ServerPublic kyber.Point
+}
+
+// Record is the registration record uploaded by the client, which the server
+// stores along with the credential id of the client.
+type Record struct {
+	ClientPublic kyber.Point
+	Nonce        []byte // envelope nonce
+	AuthTag      []byte // envelope authentication tag
+}
+
+// ClientRegistrationState is the state kept by the client during
+// registration.
+type ClientRegistrationState struct {
+	suite    Suite
+	password []byte
+	blind    kyber.Scalar
+}
+
+// ClientRegistration starts the registration of a client with the given
+// password.
+func ClientRegistration(suite Suite, password []byte) (*ClientRegistrationState, *RegistrationRequest, error) {
+	blind, alpha, err := blindPassword(suite, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	state := &ClientRegistrationState{suite: suite, password: password, blind: blind}
+	return state, &RegistrationRequest{Alpha: alpha}, nil
+}
+
+// ServerRegistration evaluates the OPRF on the blinded password of the
+// client with credential id.
+func ServerRegistration(suite Suite, server *Server, id []byte, req *RegistrationRequest) (*RegistrationResponse, error) {
+	beta, err := server.evaluate(suite, id, req.Alpha)
+	if err != nil {
+		return nil, err
+	}
+	return &RegistrationResponse{Beta: beta, ServerPublic: server.Public}, nil
+}
+
+// Upload completes the registration. It derives the key pair of the client
+// from the OPRF output and returns the record to send to the server.
+func (c *ClientRegistrationState) Upload(resp *RegistrationResponse) (*Record, error) {
+	rwd, err := randomizedPassword(c.suite, c.password, c.blind, resp.Beta)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceLen)
+	random.Bytes(nonce, c.suite.RandomStream())
+	env, err := newEnvelope(c.suite, rwd, nonce, resp.ServerPublic)
+	if err != nil {
+		return nil, err
+	}
+	return &Record{
+		ClientPublic: c.suite.Point().Mul(env.private, nil),
+		Nonce:        nonce,
+		AuthTag:      env.tag,
+	}, nil
+}
+
+// KE1 is the first login message, sent by the client.
+type KE1 struct {
+	Alpha     kyber.Point // blinded password
+	Nonce     []byte
+	Ephemeral kyber.Point
+}
+
+// KE2 is the second login message, sent by the server.
+type KE2 struct {
+	Beta         kyber.Point // evaluated blinded password
+	ServerPublic kyber.Point
+	EnvNonce     []byte
+	EnvAuthTag   []byte
+	Nonce        []byte
+	Ephemeral    kyber.Point
+	MAC          []byte
+}
+
+// KE3 is the last login message, sent by the client.
+type KE3 struct {
+	MAC []byte
+}
+
+// ClientLoginState is the state kept by the client during login.
+type ClientLoginState struct {
+	suite     Suite
+	password  []byte
+	blind     kyber.Scalar
+	ephemeral kyber.Scalar
+	ke1       *KE1
+}
+
+// ServerLoginState is the state kept by the server during login.
+type ServerLoginState struct {
+	clientMAC  []byte
+	sessionKey []byte
+}
+
+// ClientLogin starts a login with the given password.
+func ClientLogin(suite Suite, password []byte) (*ClientLoginState, *KE1, error) {
+	blind, alpha, err := blindPassword(suite, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	eph := suite.Scalar().Pick(suite.RandomStream())
+	nonce := make([]byte, nonceLen)
+	random.Bytes(nonce, suite.RandomStream())
+	ke1 := &KE1{
+		Alpha:     alpha,
+		Nonce:     nonce,
+		Ephemeral: suite.Point().Mul(eph, nil),
+	}
+	state := &ClientLoginState{
+		suite:     suite,
+		password:  password,
+		blind:     blind,
+		ephemeral: eph,
+		ke1:       ke1,
+	}
+	return state, ke1, nil
+}
+
+// ServerLogin answers the login request ke1 of the client with credential id
+// and registration record. If no client is registered with id, record must
+// be nil: the server then answers with a fake record, on which the login
+// fails at the client as with a wrong password.
+func ServerLogin(suite Suite, server *Server, id []byte, record *Record, ke1 *KE1) (*ServerLoginState, *KE2, error) {
+	beta, err := server.evaluate(suite, id, ke1.Alpha)
+	if err != nil {
+		return nil, nil, err
+	}
+	if record == nil {
+		record = server.fakeRecord(suite, id)
+	}
+	eph := suite.Scalar().Pick(suite.RandomStream())
+	nonce := make([]byte, nonceLen)
+	random.Bytes(nonce, suite.RandomStream())
+	ke2 := &KE2{
+		Beta:         beta,
+		ServerPublic: server.Public,
+		EnvNonce:     record.Nonce,
+		EnvAuthTag:   record.AuthTag,
+		Nonce:        nonce,
+		Ephemeral:    suite.Point().Mul(eph, nil),
+	}
+
+	// 3DH: eS·eC, sS·eC, eS·pkC
+	dh := []kyber.Point{
+		suite.Point().Mul(eph, ke1.Ephemeral),
+		suite.Point().Mul(server.Private, ke1.Ephemeral),
+		suite.Point().Mul(eph, record.ClientPublic),
+	}
+	keys, err := deriveKeys(suite, dh, ke1, ke2)
+	if err != nil {
+		return nil, nil, err
+	}
+	ke2.MAC = keys.serverMAC
+	return &ServerLoginState{clientMAC: keys.clientMAC, sessionKey: keys.session}, ke2, nil
+}
+
+// Finalize processes the answer of the server. It recovers the private key of
+// the client, authenticates the server and returns the last message for the
+// server along with the session key. It returns an error if the password is
+// wrong or the server cannot be authenticated.
+func (c *ClientLoginState) Finalize(ke2 *KE2) (*KE3, []byte, error) {
+	rwd, err := randomizedPassword(c.suite, c.password, c.blind, ke2.Beta)
+	if err != nil {
+		return nil, nil, err
+	}
+	env, err := newEnvelope(c.suite, rwd, ke2.EnvNonce, ke2.ServerPublic)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hmac.Equal(env.tag, ke2.EnvAuthTag) {
+		return nil, nil, errors.New("opaque: invalid envelope, wrong password")
+	}
+
+	// 3DH: eC·eS, eC·pkS, sC·eS
+	dh := []kyber.Point{
+		c.suite.Point().Mul(c.ephemeral, ke2.Ephemeral),
+		c.suite.Point().Mul(c.ephemeral, ke2.ServerPublic),
+		c.suite.Point().Mul(env.private, ke2.Ephemeral),
+	}
+	keys, err := deriveKeys(c.suite, dh, c.ke1, ke2)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hmac.Equal(keys.serverMAC, ke2.MAC) {
+		return nil, nil, errors.New("opaque: invalid server MAC")
+	}
+	return &KE3{MAC: keys.clientMAC}, keys.session, nil
+}
+
+// Finish checks the last message of the client and returns the session key.
+func (s *ServerLoginState) Finish(ke3 *KE3) ([]byte, error) {
+	if subtle.ConstantTimeCompare(s.clientMAC, ke3.MAC) != 1 {
+		return nil, errors.New("opaque: invalid client MAC")
+	}
+	return s.sessionKey, nil
+}
+
+// blindPassword maps the password to a point and blinds it with a random
+// scalar, which it returns with the blinded point.
+func blindPassword(suite Suite, password []byte) (kyber.Scalar, kyber.Point, error) {
+	P, err := hashPassword(suite, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	blind := suite.Scalar().Pick(suite.RandomStream())
+	return blind, suite.Point().Mul(blind, P), nil
+}
+
+// hashPassword maps the password to a point of the group with the hash to
+// curve function of RFC 9380, so only the groups of package hashtopoint are
+// supported.
+func hashPassword(suite Suite, password []byte) (kyber.Point, error) {
+	return hashtopoint.HashToPoint(suite, []byte("OPAQUE-HashToGroup"), password)
+}
+
+// randomizedPassword unblinds the OPRF output and hashes it with the
+// password.
+func randomizedPassword(suite Suite, password []byte, blind kyber.Scalar, beta kyber.Point) ([]byte, error) {
+	inv := suite.Scalar().Inv(blind)
+	n := suite.Point().Mul(inv, beta)
+	h := suite.Hash()
+	_, _ = h.Write(password)
+	if _, err := n.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+type envelope struct {
+	private kyber.Scalar
+	tag     []byte
+}
+
+// newEnvelope derives the private key of the client and the authentication
+// tag binding it to the server public key from the randomized password.
+func newEnvelope(suite Suite, rwd, nonce []byte, server kyber.Point) (*envelope, error) {
+	authKey := expand(suite, rwd, nonce, "AuthKey")
+	seed := expand(suite, rwd, nonce, "PrivateKey")
+	mac := hmac.New(suite.Hash, authKey)
+	_, _ = mac.Write(nonce)
+	if _, err := server.MarshalTo(mac); err != nil {
+		return nil, err
+	}
+	return &envelope{
+		private: suite.Scalar().Pick(suite.XOF(seed)),
+		tag:     mac.Sum(nil),
+	}, nil
+}
+
+type sessionKeys struct {
+	serverMAC []byte
+	clientMAC []byte
+	session   []byte
+}
+
+// deriveKeys derives the MAC keys and the session key from the 3DH shared
+// secrets, and computes the MACs over the transcript.
+func deriveKeys(suite Suite, dh []kyber.Point, ke1 *KE1, ke2 *KE2) (*sessionKeys, error) {
+	ikm := suite.Hash()
+	for _, p := range dh {
+		if _, err := p.MarshalTo(ikm); err != nil {
+			return nil, err
+		}
+	}
+
+	transcript := suite.Hash()
+	_, _ = transcript.Write([]byte("OPAQUE"))
+	for _, p := range []kyber.Point{ke1.Alpha, ke1.Ephemeral, ke2.Beta, ke2.ServerPublic, ke2.Ephemeral} {
+		if _, err := p.MarshalTo(transcript); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range [][]byte{ke1.Nonce, ke2.EnvNonce, ke2.EnvAuthTag, ke2.Nonce} {
+		_, _ = transcript.Write(b)
+	}
+	preamble := transcript.Sum(nil)
+
+	secret := ikm.Sum(nil)
+	serverKey := expand(suite, secret, preamble, "ServerMAC")
+	clientKey := expand(suite, secret, preamble, "ClientMAC")
+	keys := &sessionKeys{session: expand(suite, secret, preamble, "SessionKey")}
+
+	mac := hmac.New(suite.Hash, serverKey)
+	_, _ = mac.Write(preamble)
+	keys.serverMAC = mac.Sum(nil)
+	mac = hmac.New(suite.Hash, clientKey)
+	_, _ = mac.Write(preamble)
+	_, _ = mac.Write(keys.serverMAC)
+	keys.clientMAC = mac.Sum(nil)
+	return keys, nil
+}
+
+// expand derives a key of the size of the suite's hash from secret with HKDF.
+func expand(suite Suite, secret, salt []byte, label string) []byte {
+	return kdf.HKDF(suite, secret, salt, []byte(label), suite.Hash().Size())
+}
@@ -0,0 +1,184 @@
+package opaque
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+func register(t *testing.T, suite Suite, server *Server, id, password []byte) *Record {
+	state, req, err := ClientRegistration(suite, password)
+	require.Nil(t, err)
+	resp, err := ServerRegistration(suite, server, id, req)
+	require.Nil(t, err)
+	record, err := state.Upload(resp)
+	require.Nil(t, err)
+	return record
+}
+
+func testOPAQUE(t *testing.T, suite Suite) {
+	server := ServerSetup(suite)
+	id := []byte("alice@example.com")
+	password := []byte("correct horse battery staple")
+	record := register(t, suite, server, id, password)
+
+	client, ke1, err := ClientLogin(suite, password)
+	require.Nil(t, err)
+	sstate, ke2, err := ServerLogin(suite, server, id, record, ke1)
+	require.Nil(t, err)
+	ke3, clientKey, err := client.Finalize(ke2)
+	require.Nil(t, err)
+	serverKey, err := sstate.Finish(ke3)
+	require.Nil(t, err)
+	require.Equal(t, clientKey, serverKey)
+
+	// a second login yields another session key
+	client, ke1, err = ClientLogin(suite, password)
+	require.Nil(t, err)
+	_, ke2, err = ServerLogin(suite, server, id, record, ke1)
+	require.Nil(t, err)
+	_, clientKey2, err := client.Finalize(ke2)
+	require.Nil(t, err)
+	require.NotEqual(t, clientKey, clientKey2)
+}
+
+func TestOPAQUE(t *testing.T) {
+	testOPAQUE(t, edwards25519.NewBlakeSHA256Ed25519())
+}
+
+func TestOPAQUERistretto(t *testing.T) {
+	testOPAQUE(t, edwards25519.NewBlakeSHA256Ristretto255())
+}
+
+func TestOPAQUEWrongPassword(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	server := ServerSetup(suite)
+	id := []byte("alice@example.com")
+	password := []byte("correct horse battery staple")
+	record := register(t, suite, server, id, password)
+
+	client, ke1, err := ClientLogin(suite, []byte("Tr0ub4dor&3"))
+	require.Nil(t, err)
+	sstate, ke2, err := ServerLogin(suite, server, id, record, ke1)
+	require.Nil(t, err)
+	ke3, key, err := client.Finalize(ke2)
+	require.Error(t, err)
+	require.Nil(t, ke3)
+	require.Nil(t, key)
+
+	// the server cannot be fooled into accepting a forged last message
+	_, err = sstate.Finish(&KE3{MAC: make([]byte, len(ke2.MAC))})
+	require.Error(t, err)
+}
+
+func TestOPAQUEBlinding(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	password := []byte("correct horse battery staple")
+
+	// the server only sees the password blinded by a fresh random scalar
+	_, ke1, err := ClientLogin(suite, password)
+	require.Nil(t, err)
+	_, ke1b, err := ClientLogin(suite, password)
+	require.Nil(t, err)
+	require.False(t, ke1.Alpha.Equal(ke1b.Alpha))
+	P, err := hashPassword(suite, password)
+	require.Nil(t, err)
+	require.False(t, ke1.Alpha.Equal(P))
+}
+
+func TestOPAQUEWrongServer(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	server := ServerSetup(suite)
+	id := []byte("alice@example.com")
+	password := []byte("correct horse battery staple")
+	record := register(t, suite, server, id, password)
+
+	// another server with the stolen record cannot impersonate the server
+	client, ke1, err := ClientLogin(suite, password)
+	require.Nil(t, err)
+	_, ke2, err := ServerLogin(suite, ServerSetup(suite), id, record, ke1)
+	require.Nil(t, err)
+	_, _, err = client.Finalize(ke2)
+	require.Error(t, err)
+}
+
+func TestOPAQUERestoredServer(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	server := ServerSetup(suite)
+	id := []byte("alice@example.com")
+	password := []byte("correct horse battery staple")
+	record := register(t, suite, server, id, password)
+
+	// a server restored from its private key and seed accepts the record
+	restored, err := NewServer(suite, server.Private, server.Seed())
+	require.Nil(t, err)
+	require.True(t, restored.Public.Equal(server.Public))
+	client, ke1, err := ClientLogin(suite, password)
+	require.Nil(t, err)
+	sstate, ke2, err := ServerLogin(suite, restored, id, record, ke1)
+	require.Nil(t, err)
+	ke3, clientKey, err := client.Finalize(ke2)
+	require.Nil(t, err)
+	serverKey, err := sstate.Finish(ke3)
+	require.Nil(t, err)
+	require.Equal(t, clientKey, serverKey)
+
+	_, err = NewServer(suite, server.Private, server.Seed()[1:])
+	require.Error(t, err)
+}
+
+func TestOPAQUEInvalidAlpha(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	server := ServerSetup(suite)
+	id := []byte("alice@example.com")
+	password := []byte("correct horse battery staple")
+	record := register(t, suite, server, id, password)
+
+	// (0, -1) has order 2
+	T := suite.Point()
+	require.Nil(t, T.UnmarshalBinary([]byte{
+		0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+	}))
+	_, ke1, err := ClientLogin(suite, password)
+	require.Nil(t, err)
+	for _, alpha := range []kyber.Point{
+		nil,
+		suite.Point().Null(),
+		T,
+		suite.Point().Add(ke1.Alpha, T),
+	} {
+		_, err := ServerRegistration(suite, server, id, &RegistrationRequest{Alpha: alpha})
+		require.Equal(t, errInvalidPoint, err)
+		_, _, err = ServerLogin(suite, server, id, record, &KE1{Alpha: alpha, Nonce: ke1.Nonce, Ephemeral: ke1.Ephemeral})
+		require.Equal(t, errInvalidPoint, err)
+	}
+}
+
+func TestOPAQUEUnknownID(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	server := ServerSetup(suite)
+	password := []byte("correct horse battery staple")
+	record := register(t, suite, server, []byte("alice@example.com"), password)
+
+	// the server answers for an unknown id as for a registered one, with
+	// the same envelope at every login
+	id := []byte("bob@example.com")
+	var envelopes [][]byte
+	for i := 0; i < 2; i++ {
+		client, ke1, err := ClientLogin(suite, password)
+		require.Nil(t, err)
+		_, ke2, err := ServerLogin(suite, server, id, nil, ke1)
+		require.Nil(t, err)
+		require.Len(t, ke2.EnvNonce, len(record.Nonce))
+		require.Len(t, ke2.EnvAuthTag, len(record.AuthTag))
+		envelopes = append(envelopes, append(ke2.EnvNonce, ke2.EnvAuthTag...))
+		_, _, err = client.Finalize(ke2)
+		require.Error(t, err)
+	}
+	require.Equal(t, envelopes[0], envelopes[1])
+}
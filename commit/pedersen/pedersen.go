@@ -0,0 +1,64 @@
+// Package pedersen implements Pedersen commitments to scalars.
+//
+// A commitment to a value v is C = v·G + r·H, where G is the standard base
+// point of the group, H a second generator whose discrete logarithm with
+// respect to G is unknown, and r a random blinding scalar. Commitments are
+// perfectly hiding and computationally binding under the discrete logarithm
+// assumption. They are additively homomorphic: the sum of the commitments to
+// v1 and v2 with blinding scalars r1 and r2 opens to v1+v2 with r1+r2.
+package pedersen
+
+import (
+	"errors"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed by the package pedersen.
+type Suite interface {
+	kyber.Group
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// ErrInvalidOpening is returned by Open when a commitment does not match the
+// given value and blinding scalar.
+var ErrInvalidOpening = errors.New("pedersen: invalid opening")
+
+// Setup returns the second generator H. It is derived deterministically from
+// the base point of the group by hashing, so that nobody knows its discrete
+// logarithm and every party computes the same H.
+func Setup(suite Suite) kyber.Point {
+	b, err := suite.Point().Base().MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return suite.Point().Pick(suite.XOF(append([]byte("pedersen-H"), b...)))
+}
+
+// Commit returns a commitment to value along with the random blinding scalar
+// needed to open it.
+func Commit(suite Suite, h kyber.Point, value kyber.Scalar) (kyber.Point, kyber.Scalar) {
+	r := suite.Scalar().Pick(suite.RandomStream())
+	return commit(suite, h, value, r), r
+}
+
+// Open checks that c is a commitment to value with blinding scalar r. It
+// returns ErrInvalidOpening otherwise.
+func Open(suite Suite, h, c kyber.Point, value, r kyber.Scalar) error {
+	if !commit(suite, h, value, r).Equal(c) {
+		return ErrInvalidOpening
+	}
+	return nil
+}
+
+// Add returns the sum of two commitments, which is a commitment to the sum of
+// their values with the sum of their blinding scalars.
+func Add(c1, c2 kyber.Point) kyber.Point {
+	return c1.Clone().Add(c1, c2)
+}
+
+func commit(suite Suite, h kyber.Point, value, r kyber.Scalar) kyber.Point {
+	c := suite.Point().Mul(value, nil)
+	return c.Add(c, suite.Point().Mul(r, h))
+}
@@ -0,0 +1,46 @@
+package pedersen
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func TestCommitOpen(t *testing.T) {
+	h := Setup(suite)
+	require.True(t, h.Equal(Setup(suite)))
+	require.False(t, h.Equal(suite.Point().Base()))
+
+	v := suite.Scalar().SetInt64(42)
+	c, r := Commit(suite, h, v)
+	require.Nil(t, Open(suite, h, c, v, r))
+
+	// the same value gives another commitment every time
+	c2, _ := Commit(suite, h, v)
+	require.False(t, c.Equal(c2))
+
+	require.Equal(t, ErrInvalidOpening, Open(suite, h, c, suite.Scalar().SetInt64(43), r))
+	require.Equal(t, ErrInvalidOpening, Open(suite, h, c, v, suite.Scalar().One()))
+	require.Equal(t, ErrInvalidOpening, Open(suite, suite.Point().Base(), c, v, r))
+}
+
+func TestCommitAdd(t *testing.T) {
+	h := Setup(suite)
+	v1 := suite.Scalar().Pick(suite.RandomStream())
+	v2 := suite.Scalar().Pick(suite.RandomStream())
+	c1, r1 := Commit(suite, h, v1)
+	c2, r2 := Commit(suite, h, v2)
+
+	c := Add(c1, c2)
+	v := suite.Scalar().Add(v1, v2)
+	r := suite.Scalar().Add(r1, r2)
+	require.Nil(t, Open(suite, h, c, v, r))
+	require.Equal(t, ErrInvalidOpening, Open(suite, h, c, v, r1))
+
+	// the operands are left unchanged
+	require.Nil(t, Open(suite, h, c1, v1, r1))
+	require.Nil(t, Open(suite, h, c2, v2, r2))
+}
@@ -14,6 +14,10 @@ import (
 	"golang.org/x/crypto/hkdf"
 )
 
+// ErrDecryptFailed is returned when a ciphertext cannot be decrypted, because
+// it was altered or encrypted to another key.
+var ErrDecryptFailed = errors.New("ecies: decryption failed")
+
 // Encrypt first computes a shared DH key using the given public key, then
 // HKDF-derives a symmetric key (and nonce) from that, and finally uses these
 // values to encrypt the given message via AES-GCM. If the hash input parameter
@@ -37,7 +41,7 @@ func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func()
 	// HKDF-derived key for AES-GCM, the nonce for AES-GCM can be an arbitrary
 	// (even static) value. We derive it here simply via HKDF as well.)
 	len := 32 + 12
-	buf, err := deriveKey(hash, dh, len)
+	buf, err := deriveKey(hash, dh, len, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,14 +86,17 @@ func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() ha
 	// Reconstruct the ephemeral elliptic curve point
 	R := group.Point()
 	l := group.PointLen()
+	if len(ctx) < l {
+		return nil, ErrDecryptFailed
+	}
 	if err := R.UnmarshalBinary(ctx[:l]); err != nil {
-		return nil, err
+		return nil, ErrDecryptFailed
 	}
 
 	// Compute shared DH key and derive the symmetric key and nonce via HKDF
 	dh := group.Point().Mul(private, R)
 	len := 32 + 12
-	buf, err := deriveKey(hash, dh, len)
+	buf, err := deriveKey(hash, dh, len, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,15 +112,19 @@ func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() ha
 	if err != nil {
 		return nil, err
 	}
-	return aesgcm.Open(nil, nonce, ctx[l:], nil)
+	m, err := aesgcm.Open(nil, nonce, ctx[l:], nil)
+	if err != nil {
+		return nil, ErrDecryptFailed
+	}
+	return m, nil
 }
 
-func deriveKey(hash func() hash.Hash, dh kyber.Point, len int) ([]byte, error) {
+func deriveKey(hash func() hash.Hash, dh kyber.Point, len int, info []byte) ([]byte, error) {
 	dhb, err := dh.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
-	hkdf := hkdf.New(hash, dhb, nil, nil)
+	hkdf := hkdf.New(hash, dhb, nil, info)
 	key := make([]byte, len, len)
 	n, err := hkdf.Read(key)
 	if err != nil {
@@ -44,3 +44,36 @@ func TestECIESFailCiphertext(t *testing.T) {
 	_, err = Decrypt(suite, private, ciphertext, nil)
 	require.NotNil(t, err)
 }
+
+func TestECIESErrDecryptFailed(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	ciphertext, err := Encrypt(suite, public, []byte("Hello ECIES"), nil)
+	require.Nil(t, err)
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	_, err = Decrypt(suite, private, ciphertext, nil)
+	require.Equal(t, ErrDecryptFailed, err)
+	_, err = Decrypt(suite, private, ciphertext[:suite.PointLen()-1], nil)
+	require.Equal(t, ErrDecryptFailed, err)
+}
+
+func TestECIESErrDecryptFailedPoint(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	ciphertext, err := Encrypt(suite, public, []byte("Hello ECIES"), nil)
+	require.Nil(t, err)
+	l := suite.PointLen()
+
+	// any change of the ephemeral point, whether it still decodes or not
+	invalid := false
+	for i := 0; i < l; i++ {
+		altered := append([]byte{}, ciphertext...)
+		altered[i] ^= 0x01
+		invalid = invalid || suite.Point().UnmarshalBinary(altered[:l]) != nil
+		_, err = Decrypt(suite, private, altered, nil)
+		require.Equal(t, ErrDecryptFailed, err, "byte %d", i)
+	}
+	require.True(t, invalid, "no change gave an invalid point")
+}
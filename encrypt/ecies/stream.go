@@ -0,0 +1,144 @@
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+)
+
+// segmentSize is the size of the plaintext segments of a stream.
+const segmentSize = 64 * 1024
+
+var streamInfo = []byte("ecies stream")
+
+// EncryptStream encrypts the content of r to the given public key and writes
+// the ciphertext to w, without holding the whole message in memory. As with
+// Encrypt, the ciphertext starts with an ephemeral point, after which the
+// message is split in segments sealed by AES-GCM under a key derived with
+// HKDF. The nonce of a segment is its index together with a flag set on the
+// last segment only, so that segments cannot be reordered, dropped or
+// truncated without DecryptStream failing. If the hash input parameter is nil
+// then SHA256 is used as a default.
+func EncryptStream(group kyber.Group, public kyber.Point, r io.Reader, w io.Writer, hash func() hash.Hash) error {
+	if hash == nil {
+		hash = sha256.New
+	}
+	x := group.Scalar().Pick(random.New())
+	R := group.Point().Mul(x, nil)
+	aead, err := streamAEAD(hash, group.Point().Mul(x, public))
+	if err != nil {
+		return err
+	}
+	if _, err := R.MarshalTo(w); err != nil {
+		return err
+	}
+
+	// read one segment ahead to know which one is the last
+	cur := make([]byte, segmentSize)
+	next := make([]byte, segmentSize)
+	n, err := io.ReadFull(r, cur)
+	for i := uint64(0); ; i++ {
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return err
+		}
+		last := err != nil
+		var m int
+		if !last {
+			m, err = io.ReadFull(r, next)
+			if err == io.EOF {
+				last = true
+			}
+		}
+		if _, err := w.Write(aead.Seal(nil, streamNonce(i, last), cur[:n], nil)); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+		cur, next, n = next, cur, m
+	}
+}
+
+// DecryptStream decrypts the ciphertext read from r with the private key and
+// writes the plaintext to w. The segments are written as they are
+// authenticated, so an error means that what was written so far must be
+// discarded. It returns ErrDecryptFailed for an invalid ciphertext.
+func DecryptStream(group kyber.Group, private kyber.Scalar, r io.Reader, w io.Writer, hash func() hash.Hash) error {
+	if hash == nil {
+		hash = sha256.New
+	}
+	buf := make([]byte, group.PointLen())
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return ErrDecryptFailed
+	}
+	R := group.Point()
+	if err := R.UnmarshalBinary(buf); err != nil {
+		return ErrDecryptFailed
+	}
+	aead, err := streamAEAD(hash, group.Point().Mul(private, R))
+	if err != nil {
+		return err
+	}
+
+	segLen := segmentSize + aead.Overhead()
+	cur := make([]byte, segLen)
+	next := make([]byte, segLen)
+	n, err := io.ReadFull(r, cur)
+	for i := uint64(0); ; i++ {
+		if err == io.EOF {
+			// the last segment is missing
+			return ErrDecryptFailed
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		last := err != nil
+		var m int
+		if !last {
+			m, err = io.ReadFull(r, next)
+			if err == io.EOF {
+				last = true
+			}
+		}
+		plain, openErr := aead.Open(cur[:0], streamNonce(i, last), cur[:n], nil)
+		if openErr != nil {
+			return ErrDecryptFailed
+		}
+		if _, err := w.Write(plain); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+		cur, next, n = next, cur, m
+	}
+}
+
+func streamAEAD(hash func() hash.Hash, dh kyber.Point) (cipher.AEAD, error) {
+	key, err := deriveKey(hash, dh, 32, streamInfo)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// streamNonce returns the nonce of the i-th segment, whose last byte tells
+// whether it is the last segment.
+func streamNonce(i uint64, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[3:11], i)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
@@ -0,0 +1,66 @@
+package ecies
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestECIESStream(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 1 << 20} {
+		message := make([]byte, size)
+		random.Bytes(message, random.New())
+		var ciphertext bytes.Buffer
+		require.Nil(t, EncryptStream(suite, public, bytes.NewReader(message), &ciphertext, suite.Hash))
+
+		var plaintext bytes.Buffer
+		require.Nil(t, DecryptStream(suite, private, bytes.NewReader(ciphertext.Bytes()), &plaintext, suite.Hash))
+		require.True(t, bytes.Equal(message, plaintext.Bytes()), "size %d", size)
+	}
+}
+
+func TestECIESStreamTampered(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	message := make([]byte, 3*segmentSize)
+	var buf bytes.Buffer
+	require.Nil(t, EncryptStream(suite, public, bytes.NewReader(message), &buf, nil))
+	ciphertext := buf.Bytes()
+	segLen := segmentSize + 16
+	l := suite.PointLen()
+
+	decrypt := func(c []byte) error {
+		var plaintext bytes.Buffer
+		return DecryptStream(suite, private, bytes.NewReader(c), &plaintext, nil)
+	}
+	require.Nil(t, decrypt(ciphertext))
+
+	altered := append([]byte{}, ciphertext...)
+	altered[l+segLen+10] ^= 1
+	require.Equal(t, ErrDecryptFailed, decrypt(altered))
+
+	// truncation at a segment boundary
+	require.Equal(t, ErrDecryptFailed, decrypt(ciphertext[:l+2*segLen]))
+	require.Equal(t, ErrDecryptFailed, decrypt(ciphertext[:l]))
+	require.Equal(t, ErrDecryptFailed, decrypt(ciphertext[:l-1]))
+
+	// reordered segments
+	swapped := append([]byte{}, ciphertext[:l]...)
+	swapped = append(swapped, ciphertext[l+segLen:l+2*segLen]...)
+	swapped = append(swapped, ciphertext[l:l+segLen]...)
+	swapped = append(swapped, ciphertext[l+2*segLen:]...)
+	require.Equal(t, ErrDecryptFailed, decrypt(swapped))
+
+	// another key
+	other := suite.Scalar().Pick(random.New())
+	var plaintext bytes.Buffer
+	require.Equal(t, ErrDecryptFailed, DecryptStream(suite, other, bytes.NewReader(ciphertext), &plaintext, nil))
+}
@@ -0,0 +1,257 @@
+// Package hpke implements the Hybrid Public Key Encryption of RFC 9180 with
+// the HKDF-SHA256 key derivation and the AES-128-GCM AEAD.
+//
+// The key encapsulation is the DHKEM(X25519, HKDF-SHA256) of the RFC, or the
+// same construction on the group of a kyber suite. The four modes Base, PSK,
+// Auth and AuthPSK are selected through Options.
+//
+// A sender and a recipient obtain matching contexts from SetupSender and
+// SetupRecipient, then encrypt a sequence of messages with Seal and Open:
+//
+//	enc, sender, err := hpke.SetupSender(kem, pubR, info, nil)
+//	ct, err := sender.Seal(aad, msg)
+//	// send enc and ct
+//	recipient, err := hpke.SetupRecipient(kem, enc, privR, info, nil)
+//	msg, err := recipient.Open(aad, ct)
+//
+// The functions Seal and Open do both steps for a single message.
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Suite represents the set of functionalities needed by a KEM built on a
+// kyber group.
+type Suite interface {
+	kyber.Group
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// Mode is the HPKE mode of a context.
+type Mode byte
+
+// The modes of RFC 9180.
+const (
+	ModeBase    Mode = 0x00
+	ModePSK     Mode = 0x01
+	ModeAuth    Mode = 0x02
+	ModeAuthPSK Mode = 0x03
+)
+
+const (
+	kdfHKDFSHA256 = 0x0001
+	aeadAES128GCM = 0x0001
+
+	nSecret = 32 // size of the KEM shared secret and of HKDF-SHA256 outputs
+	nKey    = 16 // key size of AES-128-GCM
+	nNonce  = 12 // nonce size of AES-128-GCM
+)
+
+// Options selects the modes other than Base. A nil *Options selects the Base
+// mode.
+type Options struct {
+	// PSK and PSKID are the pre-shared key and its identifier of the PSK
+	// modes.
+	PSK, PSKID []byte
+	// SenderPrivate is the private key of the sender in the Auth modes; it is
+	// only used by SetupSender.
+	SenderPrivate []byte
+	// SenderPublic is the public key of the sender in the Auth modes; it is
+	// only used by SetupRecipient.
+	SenderPublic []byte
+}
+
+// Context is the encryption context of a sender or of a recipient. Each call
+// to Seal or Open uses the next nonce of the sequence, so the messages must be
+// opened in the order they were sealed.
+type Context struct {
+	suiteID        []byte
+	aead           cipher.AEAD
+	baseNonce      []byte
+	seq            uint64
+	exporterSecret []byte
+}
+
+// SetupSender encapsulates a shared secret to the public key pubR and returns
+// it along with the context of the sender.
+func SetupSender(kem KEM, pubR, info []byte, opts *Options) (enc []byte, ctx *Context, err error) {
+	ikmE := make([]byte, kem.secretLen())
+	random.Bytes(ikmE, random.New())
+	return setupSender(kem, pubR, info, opts, ikmE)
+}
+
+func setupSender(kem KEM, pubR, info []byte, opts *Options, ikmE []byte) ([]byte, *Context, error) {
+	var privS []byte
+	if opts != nil {
+		privS = opts.SenderPrivate
+	}
+	shared, enc, err := encap(kem, pubR, privS, ikmE)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := keySchedule(kem, mode(opts, privS), shared, info, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, ctx, nil
+}
+
+// SetupRecipient decapsulates the shared secret enc with the private key
+// privR and returns the context of the recipient.
+func SetupRecipient(kem KEM, enc, privR, info []byte, opts *Options) (*Context, error) {
+	var pubS []byte
+	if opts != nil {
+		pubS = opts.SenderPublic
+	}
+	shared, err := decap(kem, enc, privR, pubS)
+	if err != nil {
+		return nil, err
+	}
+	return keySchedule(kem, mode(opts, pubS), shared, info, opts)
+}
+
+// Seal encrypts a single message pt with the additional data aad to the
+// public key pubR.
+func Seal(kem KEM, pubR, info, aad, pt []byte, opts *Options) (encappedKey []byte, ct []byte, err error) {
+	enc, ctx, err := SetupSender(kem, pubR, info, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err = ctx.Seal(aad, pt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, ct, nil
+}
+
+// Open decrypts a message sealed by Seal with the private key privR.
+func Open(kem KEM, encappedKey, privR, info, aad, ct []byte, opts *Options) (pt []byte, err error) {
+	ctx, err := SetupRecipient(kem, encappedKey, privR, info, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Open(aad, ct)
+}
+
+// Seal encrypts pt with the additional data aad under the next nonce.
+func (c *Context) Seal(aad, pt []byte) ([]byte, error) {
+	nonce, err := c.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nil, nonce, pt, aad), nil
+}
+
+// Open decrypts ct with the additional data aad under the next nonce. The
+// nonce is only consumed if the decryption succeeds.
+func (c *Context) Open(aad, ct []byte) ([]byte, error) {
+	nonce := c.nonce()
+	pt, err := c.aead.Open(nil, nonce, ct, aad)
+	if err != nil {
+		return nil, errors.New("hpke: decryption failed")
+	}
+	if _, err := c.nextNonce(); err != nil {
+		return nil, err
+	}
+	return pt, nil
+}
+
+// Export derives a secret of length bytes bound to the context and to
+// exporterContext.
+func (c *Context) Export(exporterContext []byte, length int) ([]byte, error) {
+	if length > 255*nSecret {
+		return nil, errors.New("hpke: exported secret too long")
+	}
+	return labeledExpand(c.suiteID, c.exporterSecret, "sec", exporterContext, length), nil
+}
+
+func (c *Context) nonce() []byte {
+	nonce := make([]byte, nNonce)
+	binary.BigEndian.PutUint64(nonce[nNonce-8:], c.seq)
+	for i := range nonce {
+		nonce[i] ^= c.baseNonce[i]
+	}
+	return nonce
+}
+
+func (c *Context) nextNonce() ([]byte, error) {
+	if c.seq == ^uint64(0) {
+		return nil, errors.New("hpke: message limit reached")
+	}
+	nonce := c.nonce()
+	c.seq++
+	return nonce, nil
+}
+
+func mode(opts *Options, senderKey []byte) Mode {
+	m := ModeBase
+	if opts != nil && len(opts.PSK) > 0 {
+		m |= ModePSK
+	}
+	if senderKey != nil {
+		m |= ModeAuth
+	}
+	return m
+}
+
+func keySchedule(kem KEM, mode Mode, shared, info []byte, opts *Options) (*Context, error) {
+	var psk, pskID []byte
+	if opts != nil {
+		psk, pskID = opts.PSK, opts.PSKID
+	}
+	if (len(psk) == 0) != (len(pskID) == 0) {
+		return nil, errors.New("hpke: PSK and PSK identifier must be given together")
+	}
+
+	id := kem.ID()
+	suiteID := []byte{'H', 'P', 'K', 'E', byte(id >> 8), byte(id),
+		kdfHKDFSHA256 >> 8, kdfHKDFSHA256 & 0xff, aeadAES128GCM >> 8, aeadAES128GCM & 0xff}
+	pskIDHash := labeledExtract(suiteID, nil, "psk_id_hash", pskID)
+	infoHash := labeledExtract(suiteID, nil, "info_hash", info)
+	context := append(append([]byte{byte(mode)}, pskIDHash...), infoHash...)
+
+	secret := labeledExtract(suiteID, shared, "secret", psk)
+	key := labeledExpand(suiteID, secret, "key", context, nKey)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Context{
+		suiteID:        suiteID,
+		aead:           aead,
+		baseNonce:      labeledExpand(suiteID, secret, "base_nonce", context, nNonce),
+		exporterSecret: labeledExpand(suiteID, secret, "exp", context, nSecret),
+	}, nil
+}
+
+func labeledExtract(suiteID, salt []byte, label string, ikm []byte) []byte {
+	labeled := append([]byte("HPKE-v1"), suiteID...)
+	labeled = append(append(labeled, label...), ikm...)
+	return hkdf.Extract(sha256.New, labeled, salt)
+}
+
+func labeledExpand(suiteID, prk []byte, label string, info []byte, length int) []byte {
+	labeled := append([]byte{byte(length >> 8), byte(length)}, "HPKE-v1"...)
+	labeled = append(append(append(labeled, suiteID...), label...), info...)
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, labeled), out); err != nil {
+		// lengths are bounded by the callers
+		panic(err)
+	}
+	return out
+}
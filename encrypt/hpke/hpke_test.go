@@ -0,0 +1,192 @@
+package hpke
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.Nil(t, err)
+	return b
+}
+
+// Appendix A.1 of RFC 9180, DHKEM(X25519, HKDF-SHA256), HKDF-SHA256,
+// AES-128-GCM, first encryption and first export of each mode.
+var vectors = []struct {
+	mode                 Mode
+	ikmE, ikmR, ikmS     string
+	psk, pskID           string
+	enc, ct, exportValue string
+}{
+	{
+		mode:        ModeBase,
+		ikmE:        "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234",
+		ikmR:        "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037",
+		enc:         "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431",
+		ct:          "f938558b5d72f1a23810b4be2ab4f84331acc02fc97babc53a52ae8218a355a96d8770ac83d07bea87e13c512a",
+		exportValue: "3853fe2b4035195a573ffc53856e77058e15d9ea064de3e59f4961d0095250ee",
+	},
+	{
+		mode:        ModePSK,
+		ikmE:        "78628c354e46f3e169bd231be7b2ff1c77aa302460a26dbfa15515684c00130b",
+		ikmR:        "d4a09d09f575fef425905d2ab396c1449141463f698f8efdb7accfaff8995098",
+		psk:         testPSK,
+		pskID:       testPSKID,
+		enc:         "0ad0950d9fb9588e59690b74f1237ecdf1d775cd60be2eca57af5a4b0471c91b",
+		ct:          "e52c6fed7f758d0cf7145689f21bc1be6ec9ea097fef4e959440012f4feb73fb611b946199e681f4cfc34db8ea",
+		exportValue: "dff17af354c8b41673567db6259fd6029967b4e1aad13023c2ae5df8f4f43bf6",
+	},
+	{
+		mode:        ModeAuth,
+		ikmE:        "6e6d8f200ea2fb20c30b003a8b4f433d2f4ed4c2658d5bc8ce2fef718059c9f7",
+		ikmR:        "f1d4a30a4cef8d6d4e3b016e6fd3799ea057db4f345472ed302a67ce1c20cdec",
+		ikmS:        "94b020ce91d73fca4649006c7e7329a67b40c55e9e93cc907d282bbbff386f58",
+		enc:         "23fb952571a14a25e3d678140cd0e5eb47a0961bb18afcf85896e5453c312e76",
+		ct:          "5fd92cc9d46dbf8943e72a07e42f363ed5f721212cd90bcfd072bfd9f44e06b80fd17824947496e21b680c141b",
+		exportValue: "28c70088017d70c896a8420f04702c5a321d9cbf0279fba899b59e51bac72c85",
+	},
+	{
+		mode:        ModeAuthPSK,
+		ikmE:        "4303619085a20ebcf18edd22782952b8a7161e1dbae6e46e143a52a96127cf84",
+		ikmR:        "4b16221f3b269a88e207270b5e1de28cb01f847841b344b8314d6a622fe5ee90",
+		ikmS:        "62f77dcf5df0dd7eac54eac9f654f426d4161ec850cc65c54f8b65d2e0b4e345",
+		psk:         testPSK,
+		pskID:       testPSKID,
+		enc:         "820818d3c23993492cc5623ab437a48a0a7ca3e9639c140fe1e33811eb844b7c",
+		ct:          "a84c64df1e11d8fd11450039d4fe64ff0c8a99fca0bd72c2d4c3e0400bc14a40f27e45e141a24001697737533e",
+		exportValue: "08f7e20644bb9b8af54ad66d2067457c5f9fcb2a23d9f6cb4445c0797b330067",
+	},
+}
+
+const (
+	testPSK   = "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82"
+	testPSKID = "456e6e796e20447572696e206172616e204d6f726961"
+)
+
+func TestVectors(t *testing.T) {
+	kem := NewX25519KEM()
+	info := []byte("Ode on a Grecian Urn")
+	aad := []byte("Count-0")
+	pt := []byte("Beauty is truth, truth beauty")
+	for _, v := range vectors {
+		privR, pubR, err := kem.DeriveKeyPair(unhex(t, v.ikmR))
+		require.Nil(t, err)
+		sendOpts := &Options{PSK: unhex(t, v.psk), PSKID: unhex(t, v.pskID)}
+		recvOpts := *sendOpts
+		if v.ikmS != "" {
+			privS, pubS, err := kem.DeriveKeyPair(unhex(t, v.ikmS))
+			require.Nil(t, err)
+			sendOpts.SenderPrivate = privS
+			recvOpts.SenderPublic = pubS
+		}
+		require.Equal(t, v.mode, mode(sendOpts, sendOpts.SenderPrivate))
+		enc, sender, err := setupSender(kem, pubR, info, sendOpts, unhex(t, v.ikmE))
+		require.Nil(t, err)
+		require.Equal(t, v.enc, hex.EncodeToString(enc))
+		ct, err := sender.Seal(aad, pt)
+		require.Nil(t, err)
+		require.Equal(t, v.ct, hex.EncodeToString(ct))
+		exported, err := sender.Export(nil, 32)
+		require.Nil(t, err)
+		require.Equal(t, v.exportValue, hex.EncodeToString(exported))
+
+		recipient, err := SetupRecipient(kem, enc, privR, info, &recvOpts)
+		require.Nil(t, err)
+		decrypted, err := recipient.Open(aad, ct)
+		require.Nil(t, err)
+		require.Equal(t, pt, decrypted)
+	}
+}
+
+func TestGroupKEM(t *testing.T) {
+	kem := NewGroupKEM(edwards25519.NewBlakeSHA256Ed25519())
+	privR, pubR, err := kem.GenerateKeyPair()
+	require.Nil(t, err)
+	privS, pubS, err := kem.GenerateKeyPair()
+	require.Nil(t, err)
+	info := []byte("info")
+
+	for _, m := range []Mode{ModeBase, ModePSK, ModeAuth, ModeAuthPSK} {
+		sendOpts, recvOpts := &Options{}, &Options{}
+		if m&ModePSK != 0 {
+			sendOpts.PSK, sendOpts.PSKID = []byte("pre-shared key"), []byte("id")
+			recvOpts.PSK, recvOpts.PSKID = sendOpts.PSK, sendOpts.PSKID
+		}
+		if m&ModeAuth != 0 {
+			sendOpts.SenderPrivate = privS
+			recvOpts.SenderPublic = pubS
+		}
+		enc, sender, err := SetupSender(kem, pubR, info, sendOpts)
+		require.Nil(t, err)
+		recipient, err := SetupRecipient(kem, enc, privR, info, recvOpts)
+		require.Nil(t, err)
+		var cts [][]byte
+		for i := 0; i < 3; i++ {
+			msg := []byte{byte(m), byte(i)}
+			ct, err := sender.Seal(nil, msg)
+			require.Nil(t, err)
+			pt, err := recipient.Open(nil, ct)
+			require.Nil(t, err)
+			require.Equal(t, msg, pt)
+			cts = append(cts, ct)
+		}
+		e1, err := sender.Export([]byte("context"), 42)
+		require.Nil(t, err)
+		e2, err := recipient.Export([]byte("context"), 42)
+		require.Nil(t, err)
+		require.Equal(t, e1, e2)
+
+		// a recipient in another mode does not get the same keys
+		if m != ModeBase {
+			other, err := SetupRecipient(kem, enc, privR, info, nil)
+			require.Nil(t, err)
+			_, err = other.Open(nil, cts[0])
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	for _, kem := range []KEM{NewX25519KEM(), NewGroupKEM(edwards25519.NewBlakeSHA256Ed25519())} {
+		privR, pubR, err := kem.GenerateKeyPair()
+		require.Nil(t, err)
+		msg := []byte("Hello HPKE")
+		enc, ct, err := Seal(kem, pubR, []byte("info"), []byte("aad"), msg, nil)
+		require.Nil(t, err)
+		pt, err := Open(kem, enc, privR, []byte("info"), []byte("aad"), ct, nil)
+		require.Nil(t, err)
+		require.Equal(t, msg, pt)
+
+		_, err = Open(kem, enc, privR, []byte("info"), []byte("AAD"), ct, nil)
+		require.Error(t, err)
+		_, err = Open(kem, enc, privR, []byte("other info"), []byte("aad"), ct, nil)
+		require.Error(t, err)
+		ct[0] ^= 1
+		_, err = Open(kem, enc, privR, []byte("info"), []byte("aad"), ct, nil)
+		require.Error(t, err)
+		ct[0] ^= 1
+		otherPriv, _, err := kem.GenerateKeyPair()
+		require.Nil(t, err)
+		_, err = Open(kem, enc, otherPriv, []byte("info"), []byte("aad"), ct, nil)
+		require.Error(t, err)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	kem := NewX25519KEM()
+	_, pubR, err := kem.GenerateKeyPair()
+	require.Nil(t, err)
+	_, _, err = SetupSender(kem, pubR, nil, &Options{PSK: []byte("psk")})
+	require.Error(t, err)
+	_, _, err = SetupSender(kem, pubR, nil, &Options{PSKID: []byte("id")})
+	require.Error(t, err)
+	_, _, err = SetupSender(kem, pubR[:31], nil, nil)
+	require.Error(t, err)
+	// the all-zero point has a small order
+	_, _, err = SetupSender(kem, make([]byte, 32), nil, nil)
+	require.Error(t, err)
+}
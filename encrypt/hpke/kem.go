@@ -0,0 +1,206 @@
+package hpke
+
+import (
+	"errors"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+	"golang.org/x/crypto/curve25519"
+)
+
+// KEM is the Diffie-Hellman group of a DHKEM key encapsulation mechanism.
+// Keys are handled in their serialized form.
+type KEM interface {
+	// ID returns the identifier of the KEM in the HPKE registry.
+	ID() uint16
+	// GenerateKeyPair returns a fresh private key and its public key.
+	GenerateKeyPair() (priv, pub []byte, err error)
+	// DeriveKeyPair deterministically derives a key pair from the input
+	// keying material ikm.
+	DeriveKeyPair(ikm []byte) (priv, pub []byte, err error)
+
+	// dh computes the Diffie-Hellman shared secret of priv and pub.
+	dh(priv, pub []byte) ([]byte, error)
+	// public returns the public key of priv.
+	public(priv []byte) ([]byte, error)
+	// secretLen is the size of the input keying material of DeriveKeyPair.
+	secretLen() int
+}
+
+// KEMX25519 is the identifier of DHKEM(X25519, HKDF-SHA256).
+const KEMX25519 uint16 = 0x0020
+
+// KEMGroup is the identifier used for the DHKEM built on a kyber group. It is
+// not registered, so such a KEM only interoperates with this package.
+const KEMGroup uint16 = 0xff00
+
+var errInvalidKey = errors.New("hpke: invalid key")
+
+type x25519KEM struct{}
+
+// NewX25519KEM returns the DHKEM(X25519, HKDF-SHA256) key encapsulation
+// mechanism of RFC 9180.
+func NewX25519KEM() KEM {
+	return x25519KEM{}
+}
+
+func (x25519KEM) ID() uint16 { return KEMX25519 }
+
+func (k x25519KEM) GenerateKeyPair() ([]byte, []byte, error) {
+	return k.DeriveKeyPair(random.Bits(256, false, random.New()))
+}
+
+func (k x25519KEM) DeriveKeyPair(ikm []byte) ([]byte, []byte, error) {
+	suiteID := kemSuiteID(k)
+	prk := labeledExtract(suiteID, nil, "dkp_prk", ikm)
+	priv := labeledExpand(suiteID, prk, "sk", nil, 32)
+	pub, err := k.public(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv, pub, nil
+}
+
+func (x25519KEM) dh(priv, pub []byte) ([]byte, error) {
+	if len(priv) != curve25519.ScalarSize || len(pub) != curve25519.PointSize {
+		return nil, errInvalidKey
+	}
+	// X25519 fails on a shared secret of zero
+	return curve25519.X25519(priv, pub)
+}
+
+func (x25519KEM) public(priv []byte) ([]byte, error) {
+	if len(priv) != curve25519.ScalarSize {
+		return nil, errInvalidKey
+	}
+	return curve25519.X25519(priv, curve25519.Basepoint)
+}
+
+func (x25519KEM) secretLen() int { return 32 }
+
+type groupKEM struct {
+	suite Suite
+}
+
+// NewGroupKEM returns a DHKEM with HKDF-SHA256 on the group of the suite.
+// Public keys are marshalled points and private keys marshalled scalars.
+func NewGroupKEM(suite Suite) KEM {
+	return &groupKEM{suite}
+}
+
+func (g *groupKEM) ID() uint16 { return KEMGroup }
+
+func (g *groupKEM) GenerateKeyPair() ([]byte, []byte, error) {
+	return g.DeriveKeyPair(random.Bits(uint(8*g.secretLen()), false, g.suite.RandomStream()))
+}
+
+func (g *groupKEM) DeriveKeyPair(ikm []byte) ([]byte, []byte, error) {
+	suiteID := kemSuiteID(g)
+	prk := labeledExtract(suiteID, nil, "dkp_prk", ikm)
+	seed := labeledExpand(suiteID, prk, "sk", nil, g.secretLen())
+	x := g.suite.Scalar().Pick(g.suite.XOF(seed))
+	priv, err := x.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := g.suite.Point().Mul(x, nil).MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv, pub, nil
+}
+
+func (g *groupKEM) dh(priv, pub []byte) ([]byte, error) {
+	x, err := g.scalar(priv)
+	if err != nil {
+		return nil, err
+	}
+	P := g.suite.Point()
+	if err := P.UnmarshalBinary(pub); err != nil {
+		return nil, errInvalidKey
+	}
+	K := g.suite.Point().Mul(x, P)
+	if K.Equal(g.suite.Point().Null()) {
+		return nil, errInvalidKey
+	}
+	return K.MarshalBinary()
+}
+
+func (g *groupKEM) public(priv []byte) ([]byte, error) {
+	x, err := g.scalar(priv)
+	if err != nil {
+		return nil, err
+	}
+	return g.suite.Point().Mul(x, nil).MarshalBinary()
+}
+
+func (g *groupKEM) scalar(priv []byte) (kyber.Scalar, error) {
+	x := g.suite.Scalar()
+	if err := x.UnmarshalBinary(priv); err != nil {
+		return nil, errInvalidKey
+	}
+	return x, nil
+}
+
+func (g *groupKEM) secretLen() int { return g.suite.ScalarLen() }
+
+func kemSuiteID(k KEM) []byte {
+	id := k.ID()
+	return []byte{'K', 'E', 'M', byte(id >> 8), byte(id)}
+}
+
+// encap runs the (authenticated) encapsulation of DHKEM with the ephemeral
+// input keying material ikmE. privS is nil in the modes without Auth.
+func encap(k KEM, pubR, privS, ikmE []byte) (shared, enc []byte, err error) {
+	privE, pubE, err := k.DeriveKeyPair(ikmE)
+	if err != nil {
+		return nil, nil, err
+	}
+	dh, err := k.dh(privE, pubR)
+	if err != nil {
+		return nil, nil, err
+	}
+	kemContext := append(append([]byte{}, pubE...), pubR...)
+	if privS != nil {
+		dhS, err := k.dh(privS, pubR)
+		if err != nil {
+			return nil, nil, err
+		}
+		pubS, err := k.public(privS)
+		if err != nil {
+			return nil, nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pubS...)
+	}
+	return extractAndExpand(k, dh, kemContext), pubE, nil
+}
+
+// decap is the counterpart of encap for the private key privR. pubS is nil
+// in the modes without Auth.
+func decap(k KEM, enc, privR, pubS []byte) ([]byte, error) {
+	dh, err := k.dh(privR, enc)
+	if err != nil {
+		return nil, err
+	}
+	pubR, err := k.public(privR)
+	if err != nil {
+		return nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), pubR...)
+	if pubS != nil {
+		dhS, err := k.dh(privR, pubS)
+		if err != nil {
+			return nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pubS...)
+	}
+	return extractAndExpand(k, dh, kemContext), nil
+}
+
+func extractAndExpand(k KEM, dh, kemContext []byte) []byte {
+	suiteID := kemSuiteID(k)
+	prk := labeledExtract(suiteID, nil, "eae_prk", dh)
+	return labeledExpand(suiteID, prk, "shared_secret", kemContext, nSecret)
+}
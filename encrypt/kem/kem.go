@@ -0,0 +1,226 @@
+// Package kem defines key encapsulation mechanisms, which let a sender derive
+// a fresh shared secret together with a ciphertext that only the owner of a
+// private key can decapsulate into the same secret.
+//
+// DHKEM builds a KEM from the Diffie-Hellman of a kyber group, KyberKEM768
+// wraps the post-quantum ML-KEM-768 of FIPS 203, and HybridKEM combines a
+// classical and a post-quantum KEM so that the shared secret stays safe as
+// long as one of the two is.
+//
+// KyberKEM768 relies on the crypto/mlkem package of the standard library, and
+// is only built with Go 1.24 or newer.
+package kem
+
+import (
+	"errors"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/kdf"
+	"golang.org/x/crypto/sha3"
+)
+
+// PublicKey is the public key of a KEM: a kyber.Point for DHKEM, an
+// *mlkem.EncapsulationKey768 for KyberKEM768 and a *HybridPublicKey for
+// HybridKEM.
+type PublicKey interface{}
+
+// PrivateKey is the private key of a KEM: a kyber.Scalar for DHKEM, an
+// *mlkem.DecapsulationKey768 for KyberKEM768 and a *HybridPrivateKey for
+// HybridKEM.
+type PrivateKey interface{}
+
+// KEM is a key encapsulation mechanism.
+type KEM interface {
+	// GenerateKey returns a fresh key pair.
+	GenerateKey() (PublicKey, PrivateKey, error)
+	// Encapsulate returns a fresh shared secret and its encapsulation to pub.
+	Encapsulate(pub PublicKey) (sharedSecret, ciphertext []byte, err error)
+	// Decapsulate returns the shared secret encapsulated in ciphertext.
+	Decapsulate(priv PrivateKey, ciphertext []byte) (sharedSecret []byte, err error)
+	// CiphertextSize returns the length of the ciphertexts.
+	CiphertextSize() int
+}
+
+// SharedSecretSize is the length of the shared secrets of the KEMs of this
+// package.
+const SharedSecretSize = 32
+
+var (
+	errKeyType       = errors.New("kem: wrong key type")
+	errCiphertextLen = errors.New("kem: invalid ciphertext length")
+)
+
+// Suite represents the set of functionalities needed by DHKEM.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	kyber.Random
+}
+
+// DHKEM is the KEM of the Diffie-Hellman of a group: the ciphertext is an
+// ephemeral public key, and the shared secret is derived with HKDF from the
+// Diffie-Hellman of the ephemeral and the static keys.
+type DHKEM struct {
+	suite Suite
+}
+
+// NewDHKEM returns the DHKEM of the group of suite, whose hash function is
+// used in HKDF.
+func NewDHKEM(suite Suite) *DHKEM {
+	return &DHKEM{suite: suite}
+}
+
+// GenerateKey returns a fresh key pair, of type kyber.Point and kyber.Scalar.
+func (k *DHKEM) GenerateKey() (PublicKey, PrivateKey, error) {
+	priv := k.suite.Scalar().Pick(k.suite.RandomStream())
+	return k.suite.Point().Mul(priv, nil), priv, nil
+}
+
+// Encapsulate implements the KEM interface.
+func (k *DHKEM) Encapsulate(pub PublicKey) ([]byte, []byte, error) {
+	P, ok := pub.(kyber.Point)
+	if !ok {
+		return nil, nil, errKeyType
+	}
+	e := k.suite.Scalar().Pick(k.suite.RandomStream())
+	E := k.suite.Point().Mul(e, nil)
+	enc, err := E.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	ss, err := k.sharedSecret(k.suite.Point().Mul(e, P), E, P)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ss, enc, nil
+}
+
+// Decapsulate implements the KEM interface.
+func (k *DHKEM) Decapsulate(priv PrivateKey, ciphertext []byte) ([]byte, error) {
+	x, ok := priv.(kyber.Scalar)
+	if !ok {
+		return nil, errKeyType
+	}
+	if len(ciphertext) != k.CiphertextSize() {
+		return nil, errCiphertextLen
+	}
+	E := k.suite.Point()
+	if err := E.UnmarshalBinary(ciphertext); err != nil {
+		return nil, err
+	}
+	return k.sharedSecret(k.suite.Point().Mul(x, E), E, k.suite.Point().Mul(x, nil))
+}
+
+// CiphertextSize implements the KEM interface.
+func (k *DHKEM) CiphertextSize() int {
+	return k.suite.PointLen()
+}
+
+// sharedSecret derives the shared secret from the Diffie-Hellman dh, bound to
+// the ephemeral and the static public keys.
+func (k *DHKEM) sharedSecret(dh, E, P kyber.Point) ([]byte, error) {
+	if dh.Equal(k.suite.Point().Null()) {
+		return nil, errors.New("kem: Diffie-Hellman is the identity")
+	}
+	var ikm []byte
+	for _, X := range []kyber.Point{dh, E, P} {
+		b, err := X.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		ikm = append(ikm, b...)
+	}
+	info := []byte("kyber-dhkem " + k.suite.String())
+	return kdf.HKDF(k.suite, ikm, nil, info, SharedSecretSize), nil
+}
+
+// HybridPublicKey is the public key of a HybridKEM.
+type HybridPublicKey struct {
+	Classical, PostQuantum PublicKey
+}
+
+// HybridPrivateKey is the private key of a HybridKEM.
+type HybridPrivateKey struct {
+	Classical, PostQuantum PrivateKey
+}
+
+// HybridKEM runs a classical and a post-quantum KEM side by side. The
+// ciphertext is the concatenation of their ciphertexts, and the shared secret
+// is the hash of both shared secrets and both ciphertexts. Hashing, rather
+// than xoring the secrets, ties the secret to the ciphertexts, so that an
+// attack on one KEM cannot be used to mix and match ciphertexts.
+type HybridKEM struct {
+	classical, postQuantum KEM
+}
+
+// NewHybridKEM returns the combination of the two KEMs.
+func NewHybridKEM(classical, postQuantum KEM) *HybridKEM {
+	return &HybridKEM{classical: classical, postQuantum: postQuantum}
+}
+
+// GenerateKey returns a fresh key pair, of type *HybridPublicKey and
+// *HybridPrivateKey.
+func (k *HybridKEM) GenerateKey() (PublicKey, PrivateKey, error) {
+	pubC, privC, err := k.classical.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	pubPQ, privPQ, err := k.postQuantum.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &HybridPublicKey{pubC, pubPQ}, &HybridPrivateKey{privC, privPQ}, nil
+}
+
+// Encapsulate implements the KEM interface.
+func (k *HybridKEM) Encapsulate(pub PublicKey) ([]byte, []byte, error) {
+	P, ok := pub.(*HybridPublicKey)
+	if !ok {
+		return nil, nil, errKeyType
+	}
+	ssC, ctC, err := k.classical.Encapsulate(P.Classical)
+	if err != nil {
+		return nil, nil, err
+	}
+	ssPQ, ctPQ, err := k.postQuantum.Encapsulate(P.PostQuantum)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct := append(ctC, ctPQ...)
+	return combine(ssC, ssPQ, ct), ct, nil
+}
+
+// Decapsulate implements the KEM interface.
+func (k *HybridKEM) Decapsulate(priv PrivateKey, ciphertext []byte) ([]byte, error) {
+	x, ok := priv.(*HybridPrivateKey)
+	if !ok {
+		return nil, errKeyType
+	}
+	if len(ciphertext) != k.CiphertextSize() {
+		return nil, errCiphertextLen
+	}
+	n := k.classical.CiphertextSize()
+	ssC, err := k.classical.Decapsulate(x.Classical, ciphertext[:n])
+	if err != nil {
+		return nil, err
+	}
+	ssPQ, err := k.postQuantum.Decapsulate(x.PostQuantum, ciphertext[n:])
+	if err != nil {
+		return nil, err
+	}
+	return combine(ssC, ssPQ, ciphertext), nil
+}
+
+// CiphertextSize implements the KEM interface.
+func (k *HybridKEM) CiphertextSize() int {
+	return k.classical.CiphertextSize() + k.postQuantum.CiphertextSize()
+}
+
+func combine(ssC, ssPQ, ciphertext []byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte("kyber-hybrid-kem"))
+	h.Write(ssC)
+	h.Write(ssPQ)
+	h.Write(ciphertext)
+	return h.Sum(nil)
+}
@@ -0,0 +1,69 @@
+package kem
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+var kems = map[string]KEM{
+	"DHKEM": NewDHKEM(suite),
+}
+
+func TestKEM(t *testing.T) {
+	for name, k := range kems {
+		pub, priv, err := k.GenerateKey()
+		require.Nil(t, err, name)
+		ss, ct, err := k.Encapsulate(pub)
+		require.Nil(t, err, name)
+		require.Len(t, ss, SharedSecretSize, name)
+		require.Len(t, ct, k.CiphertextSize(), name)
+
+		ss2, err := k.Decapsulate(priv, ct)
+		require.Nil(t, err, name)
+		require.Equal(t, ss, ss2, name)
+
+		// every encapsulation gives a fresh secret
+		ss3, ct3, err := k.Encapsulate(pub)
+		require.Nil(t, err, name)
+		require.NotEqual(t, ss, ss3, name)
+		require.NotEqual(t, ct, ct3, name)
+
+		// another key pair does not decapsulate to the same secret
+		_, other, err := k.GenerateKey()
+		require.Nil(t, err, name)
+		ss4, err := k.Decapsulate(other, ct)
+		if err == nil {
+			require.NotEqual(t, ss, ss4, name)
+		}
+
+		_, err = k.Decapsulate(priv, ct[1:])
+		require.Error(t, err, name)
+		_, _, err = k.Encapsulate(priv)
+		require.Error(t, err, name)
+	}
+}
+
+func benchmarkKEM(b *testing.B, k KEM) {
+	pub, priv, err := k.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, ct, err := k.Encapsulate(pub)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := k.Decapsulate(priv, ct); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDHKEM(b *testing.B) {
+	benchmarkKEM(b, kems["DHKEM"])
+}
@@ -0,0 +1,49 @@
+// +build go1.24
+
+package kem
+
+import "crypto/mlkem"
+
+// KyberKEM768 is ML-KEM-768, the post-quantum KEM standardized in FIPS 203
+// from CRYSTALS-Kyber.
+type KyberKEM768 struct{}
+
+// NewKyberKEM768 returns the ML-KEM-768 KEM.
+func NewKyberKEM768() *KyberKEM768 {
+	return &KyberKEM768{}
+}
+
+// GenerateKey returns a fresh key pair, of type *mlkem.EncapsulationKey768 and
+// *mlkem.DecapsulationKey768.
+func (k *KyberKEM768) GenerateKey() (PublicKey, PrivateKey, error) {
+	priv, err := mlkem.GenerateKey768()
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv.EncapsulationKey(), priv, nil
+}
+
+// Encapsulate implements the KEM interface.
+func (k *KyberKEM768) Encapsulate(pub PublicKey) ([]byte, []byte, error) {
+	P, ok := pub.(*mlkem.EncapsulationKey768)
+	if !ok {
+		return nil, nil, errKeyType
+	}
+	ss, ct := P.Encapsulate()
+	return ss, ct, nil
+}
+
+// Decapsulate implements the KEM interface. As in FIPS 203, an altered
+// ciphertext gives a pseudorandom shared secret rather than an error.
+func (k *KyberKEM768) Decapsulate(priv PrivateKey, ciphertext []byte) ([]byte, error) {
+	x, ok := priv.(*mlkem.DecapsulationKey768)
+	if !ok {
+		return nil, errKeyType
+	}
+	return x.Decapsulate(ciphertext)
+}
+
+// CiphertextSize implements the KEM interface.
+func (k *KyberKEM768) CiphertextSize() int {
+	return mlkem.CiphertextSize768
+}
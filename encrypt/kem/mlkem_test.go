@@ -0,0 +1,37 @@
+// +build go1.24
+
+package kem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	kems["KyberKEM768"] = NewKyberKEM768()
+	kems["HybridKEM"] = NewHybridKEM(NewDHKEM(suite), NewKyberKEM768())
+}
+
+func TestHybridKEMAlteredCiphertext(t *testing.T) {
+	k := kems["HybridKEM"]
+	pub, priv, err := k.GenerateKey()
+	require.Nil(t, err)
+	ss, ct, err := k.Encapsulate(pub)
+	require.Nil(t, err)
+
+	// altering the post-quantum ciphertext changes the shared secret, even
+	// though ML-KEM does not return an error
+	ct[len(ct)-1] ^= 1
+	ss2, err := k.Decapsulate(priv, ct)
+	require.Nil(t, err)
+	require.NotEqual(t, ss, ss2)
+}
+
+func BenchmarkKyberKEM768(b *testing.B) {
+	benchmarkKEM(b, kems["KyberKEM768"])
+}
+
+func BenchmarkHybridKEM(b *testing.B) {
+	benchmarkKEM(b, kems["HybridKEM"])
+}
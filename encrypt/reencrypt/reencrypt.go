@@ -0,0 +1,103 @@
+// Package reencrypt implements a unidirectional ElGamal proxy re-encryption
+// scheme.
+//
+// Messages are embedded into points and ElGamal-encrypted as (K, C) =
+// (k·G, M + k·A) for a recipient key pair (a, A). The delegator can issue a
+// ReKey to a proxy, which then transforms ciphertexts for A into ciphertexts
+// for a delegate with public key B, without learning the messages.
+//
+// The ReKey is built from a fresh random r: its public part R = r·G is given
+// to the delegate, and its secret part s = a - z goes to the proxy, where
+// z = H(r·B) = H(b·R). The proxy computes C' = C - s·K = M + z·K, so that
+// (K, C') is a regular ElGamal ciphertext under the key pair (z, z·G) of the
+// delegate, which can be decrypted with Decrypt as any other ciphertext. Once
+// derived with DelegateKey, z decrypts all the ciphertexts re-encrypted with
+// that ReKey. Note that a proxy colluding with the delegate learns a.
+package reencrypt
+
+import (
+	"errors"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed by the package reencrypt.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	kyber.Random
+}
+
+// Ciphertext is an ElGamal ciphertext (K, C) = (k·G, M + k·A) of a message
+// embedded in the point M.
+type Ciphertext struct {
+	K kyber.Point
+	C kyber.Point
+}
+
+// ReKey allows a proxy to re-encrypt ciphertexts from a delegator to a
+// delegate. S must only be given to the proxy, and R to the delegate.
+type ReKey struct {
+	R kyber.Point
+	S kyber.Scalar
+}
+
+// Encrypt embeds msg into a point and encrypts it for the public key.
+// It returns an error if msg does not fit into a single point.
+func Encrypt(suite Suite, public kyber.Point, msg []byte) (*Ciphertext, error) {
+	if len(msg) > suite.Point().EmbedLen() {
+		return nil, errors.New("reencrypt: message too long to be embedded")
+	}
+	M := suite.Point().Embed(msg, suite.RandomStream())
+	k := suite.Scalar().Pick(suite.RandomStream())
+	K := suite.Point().Mul(k, nil)
+	C := suite.Point().Mul(k, public)
+	C.Add(C, M)
+	return &Ciphertext{K: K, C: C}, nil
+}
+
+// Decrypt recovers the message of an ElGamal ciphertext with the private key.
+// A delegate decrypts re-encrypted ciphertexts with the key returned by
+// DelegateKey.
+func Decrypt(suite Suite, private kyber.Scalar, ct *Ciphertext) ([]byte, error) {
+	S := suite.Point().Mul(private, ct.K)
+	M := suite.Point().Sub(ct.C, S)
+	return M.Data()
+}
+
+// ReKeyGen creates a re-encryption key from the delegator, with private key
+// private, to the delegate with public key delegate.
+func ReKeyGen(suite Suite, private kyber.Scalar, delegate kyber.Point) (*ReKey, error) {
+	r := suite.Scalar().Pick(suite.RandomStream())
+	R := suite.Point().Mul(r, nil)
+	z, err := deriveKey(suite, suite.Point().Mul(r, delegate))
+	if err != nil {
+		return nil, err
+	}
+	return &ReKey{R: R, S: suite.Scalar().Sub(private, z)}, nil
+}
+
+// ReEncrypt transforms a ciphertext for the delegator into a ciphertext for
+// the delegate. The message is never revealed to the proxy.
+func ReEncrypt(suite Suite, rekey *ReKey, ct *Ciphertext) *Ciphertext {
+	sK := suite.Point().Mul(rekey.S, ct.K)
+	return &Ciphertext{
+		K: suite.Point().Set(ct.K),
+		C: sK.Sub(ct.C, sK),
+	}
+}
+
+// DelegateKey returns the private key with which the delegate decrypts the
+// ciphertexts re-encrypted with the ReKey whose public part is R.
+func DelegateKey(suite Suite, private kyber.Scalar, R kyber.Point) (kyber.Scalar, error) {
+	return deriveKey(suite, suite.Point().Mul(private, R))
+}
+
+// deriveKey hashes a Diffie-Hellman point into a scalar.
+func deriveKey(suite Suite, dh kyber.Point) (kyber.Scalar, error) {
+	h := suite.Hash()
+	if _, err := dh.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	return suite.Scalar().SetBytes(h.Sum(nil)), nil
+}
@@ -0,0 +1,91 @@
+package reencrypt
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/key"
+	"github.com/stretchr/testify/require"
+)
+
+// Simple random stream using the random instance provided by the testing tool
+type quickstream struct {
+	rand *rand.Rand
+}
+
+func (s *quickstream) XORKeyStream(dst, src []byte) {
+	s.rand.Read(dst)
+}
+
+func (s *quickstream) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(&quickstream{rand: rand})
+}
+
+func TestReEncrypt(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	alice := key.NewKeyPair(suite)
+	bob := key.NewKeyPair(suite)
+	msg := []byte("Hello proxy")
+
+	ct, err := Encrypt(suite, alice.Public, msg)
+	require.Nil(t, err)
+	m, err := Decrypt(suite, alice.Private, ct)
+	require.Nil(t, err)
+	require.Equal(t, msg, m)
+
+	rk, err := ReKeyGen(suite, alice.Private, bob.Public)
+	require.Nil(t, err)
+	ct2 := ReEncrypt(suite, rk, ct)
+	z, err := DelegateKey(suite, bob.Private, rk.R)
+	require.Nil(t, err)
+	m, err = Decrypt(suite, z, ct2)
+	require.Nil(t, err)
+	require.Equal(t, msg, m)
+
+	// the re-encrypted ciphertext no longer opens with Alice's or Bob's
+	// private key, nor with the proxy's share of the re-encryption key
+	for _, k := range []*key.Pair{alice, bob} {
+		m, err = Decrypt(suite, k.Private, ct2)
+		require.False(t, err == nil && string(m) == string(msg))
+	}
+	m, err = Decrypt(suite, rk.S, ct)
+	require.False(t, err == nil && string(m) == string(msg))
+
+	_, err = Encrypt(suite, alice.Public, make([]byte, suite.Point().EmbedLen()+1))
+	require.Error(t, err)
+}
+
+func TestQuickReEncrypt(t *testing.T) {
+	f := func(rand *quickstream, msg []byte) bool {
+		suite := edwards25519.NewBlakeSHA256Ed25519WithRand(rand)
+		if max := suite.Point().EmbedLen(); len(msg) > max {
+			msg = msg[:max]
+		}
+		alice := key.NewKeyPair(suite)
+		bob := key.NewKeyPair(suite)
+
+		ct, err := Encrypt(suite, alice.Public, msg)
+		if err != nil {
+			return false
+		}
+		rk, err := ReKeyGen(suite, alice.Private, bob.Public)
+		if err != nil {
+			return false
+		}
+		z, err := DelegateKey(suite, bob.Private, rk.R)
+		if err != nil {
+			return false
+		}
+		m1, err1 := Decrypt(suite, alice.Private, ct)
+		m2, err2 := Decrypt(suite, z, ReEncrypt(suite, rk, ct))
+		return err1 == nil && err2 == nil &&
+			string(m1) == string(msg) && string(m2) == string(msg)
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
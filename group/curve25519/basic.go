@@ -175,26 +175,33 @@ func (P *basicPoint) Neg(A kyber.Point) kyber.Point {
 	return P
 }
 
-// Multiply point p by scalar s using the repeated doubling method.
+// Multiply point p by scalar s using the Montgomery ladder. Every bit of the
+// group order costs one conditional swap, one addition and one doubling,
+// whatever the bits of the scalar, but the big.Int arithmetic underneath is
+// still variable time.
 func (P *basicPoint) Mul(s kyber.Scalar, G kyber.Point) kyber.Point {
 	v := s.(*mod.Int).V
 	if G == nil {
 		return P.Base().Mul(s, P)
 	}
-	T := P
-	if G == P { // Must use temporary in case G == P
-		T = &basicPoint{}
-	}
-	T.Set(&P.c.null) // Initialize to identity element (0,1)
-	for i := v.BitLen() - 1; i >= 0; i-- {
-		T.double()
-		if v.Bit(i) != 0 {
-			T.Add(T, G)
-		}
-	}
-	if T != P {
-		P.Set(T)
+	R0 := &basicPoint{}
+	R0.Set(&P.c.null) // Initialize to identity element (0,1)
+	R1 := &basicPoint{}
+	R1.Set(G)
+	// R0 and R1 are swapped when the bit differs from the previous one, so
+	// that the ladder step always adds into R1 and doubles R0
+	a := []*mod.Int{&R0.x, &R0.y}
+	b := []*mod.Int{&R1.x, &R1.y}
+	var swap uint
+	for i := P.c.ladderBits(&v) - 1; i >= 0; i-- {
+		bit := v.Bit(i)
+		condSwap(bit^swap, a, b)
+		swap = bit
+		R1.Add(R0, R1)
+		R0.double()
 	}
+	condSwap(swap, a, b)
+	P.Set(R0)
 	return P
 }
 
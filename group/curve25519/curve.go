@@ -67,6 +67,29 @@ func (c *curve) ScalarLen() int {
 	return (c.order.V.BitLen() + 7) / 8
 }
 
+// ladderBits returns the number of iterations of the Montgomery ladder for
+// the scalar v, which is the bit length of the order for any reduced scalar.
+func (c *curve) ladderBits(v *big.Int) int {
+	if v.BitLen() > c.order.V.BitLen() {
+		return v.BitLen()
+	}
+	return c.order.V.BitLen()
+}
+
+// condSwap swaps the field elements a[i] and b[i] if bit is 1 and leaves
+// them unchanged if it is 0, with the same operations in both cases:
+// d = bit·(b-a), a = a+d and b = b-d.
+func condSwap(bit uint, a, b []*mod.Int) {
+	var d, m mod.Int
+	m.Init64(int64(bit), a[0].M)
+	for i := range a {
+		d.Sub(b[i], a[i])
+		d.Mul(&d, &m)
+		a[i].Add(a[i], &d)
+		b[i].Sub(b[i], &d)
+	}
+}
+
 // Create a new Scalar for this curve.
 func (c *curve) Scalar() kyber.Scalar {
 	return mod.NewInt64(0, &c.order.V)
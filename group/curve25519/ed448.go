@@ -0,0 +1,85 @@
+// +build vartime
+
+package curve25519
+
+import (
+	"crypto/cipher"
+	"crypto/sha512"
+	"hash"
+	"io"
+	"reflect"
+
+	"github.com/dedis/fixbuf"
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/internal/marshalling"
+	"github.com/dedis/kyber/util/random"
+	"github.com/dedis/kyber/xof/keccak"
+	"golang.org/x/crypto/sha3"
+)
+
+// SuiteEd448 is a cipher suite for the prime-order subgroup of the
+// Ed448-Goldilocks curve. Points use the 57-byte little-endian encoding
+// of RFC 8032.
+type SuiteEd448 struct {
+	ExtendedCurve
+}
+
+// SHA512 hash function
+func (s *SuiteEd448) Hash() hash.Hash {
+	return sha512.New()
+}
+
+// XOF returns an XOF based on SHAKE256, the hash used by Ed448.
+func (s *SuiteEd448) XOF(seed []byte) kyber.XOF {
+	return keccak.New(seed)
+}
+
+func (s *SuiteEd448) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs)
+}
+
+func (s *SuiteEd448) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs)
+}
+
+func (s *SuiteEd448) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+func (s *SuiteEd448) RandomStream() cipher.Stream {
+	return random.New()
+}
+
+// NewKey returns a secret key derived from a random 57-byte seed as in
+// RFC 8032, section 5.2.5.
+func (s *SuiteEd448) NewKey(stream cipher.Stream) kyber.Scalar {
+	var seed [57]byte
+	random.Bytes(seed[:], stream)
+	return s.seedToScalar(seed[:])
+}
+
+// seedToScalar hashes an Ed448 private key with SHAKE256 and clamps the
+// first half of the digest into a scalar.
+func (s *SuiteEd448) seedToScalar(seed []byte) kyber.Scalar {
+	var digest [114]byte
+	sha3.ShakeSum256(digest[:], seed)
+	key := digest[:57]
+	key[0] &= 252
+	key[56] = 0
+	key[55] |= 128
+
+	// The scalars of this group are big-endian.
+	return s.Scalar().SetBytes(reverse(key, key))
+}
+
+// NewShakeSHA512Ed448 returns a cipher suite based on package
+// github.com/dedis/kyber/xof/keccak, SHA-512, and the prime-order subgroup
+// of Ed448-Goldilocks.
+//
+// As for the other curves of this package, the scalars created by this
+// group interpret the bytes given to SetBytes as a big-endian integer.
+func NewShakeSHA512Ed448() *SuiteEd448 {
+	suite := new(SuiteEd448)
+	suite.Init(ParamEd448(), false)
+	return suite
+}
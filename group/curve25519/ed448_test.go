@@ -0,0 +1,63 @@
+// +build vartime
+
+package curve25519
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/sign/schnorr"
+	"github.com/dedis/kyber/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEd448(t *testing.T) {
+	test.SuiteTest(t, NewShakeSHA512Ed448())
+}
+
+func TestCompareProjectiveExtendedEd448(t *testing.T) {
+	test.CompareGroups(t, testSuite.XOF,
+		new(ProjectiveCurve).Init(ParamEd448(), false),
+		new(ExtendedCurve).Init(ParamEd448(), false))
+}
+
+// Test vector 1 of RFC 8032, section 7.4.
+func TestEd448PublicKey(t *testing.T) {
+	suite := NewShakeSHA512Ed448()
+	require.Equal(t, 57, suite.PointLen())
+
+	seed, _ := hex.DecodeString("6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b")
+	pub := suite.Point().Mul(suite.seedToScalar(seed), nil)
+	buf, err := pub.MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, "5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180", hex.EncodeToString(buf))
+
+	p := suite.Point()
+	require.Nil(t, p.UnmarshalBinary(buf))
+	require.True(t, p.Equal(pub))
+}
+
+func TestEd448CrossSuite(t *testing.T) {
+	ed448 := NewShakeSHA512Ed448()
+	ed25519 := edwards25519.NewBlakeSHA256Ed25519()
+	msg := []byte("Hello Ed448")
+
+	priv448 := ed448.NewKey(ed448.RandomStream())
+	pub448 := ed448.Point().Mul(priv448, nil)
+	priv25519 := ed25519.NewKey(ed25519.RandomStream())
+	pub25519 := ed25519.Point().Mul(priv25519, nil)
+
+	sig448, err := schnorr.Sign(ed448, priv448, msg)
+	require.Nil(t, err)
+	require.Nil(t, schnorr.Verify(ed448, pub448, msg, sig448))
+	require.Error(t, schnorr.Verify(ed25519, pub25519, msg, sig448))
+	det448, err := schnorr.SignDeterministic(ed448, priv448, msg)
+	require.Nil(t, err)
+	require.Nil(t, schnorr.Verify(ed448, pub448, msg, det448))
+
+	sig25519, err := schnorr.Sign(ed25519, priv25519, msg)
+	require.Nil(t, err)
+	require.Nil(t, schnorr.Verify(ed25519, pub25519, msg, sig25519))
+	require.Error(t, schnorr.Verify(ed448, pub448, msg, sig25519))
+}
@@ -224,26 +224,33 @@ func (P *projPoint) double() {
 	P.Z.Mul(&F, &J)
 }
 
-// Multiply point p by scalar s using the repeated doubling method.
+// Multiply point p by scalar s using the Montgomery ladder. Every bit of the
+// group order costs one conditional swap, one addition and one doubling,
+// whatever the bits of the scalar, but the big.Int arithmetic underneath is
+// still variable time.
 func (P *projPoint) Mul(s kyber.Scalar, G kyber.Point) kyber.Point {
 	v := s.(*mod.Int).V
 	if G == nil {
 		return P.Base().Mul(s, P)
 	}
-	T := P
-	if G == P { // Must use temporary for in-place multiply
-		T = &projPoint{}
-	}
-	T.Set(&P.c.null) // Initialize to identity element (0,1)
-	for i := v.BitLen() - 1; i >= 0; i-- {
-		T.double()
-		if v.Bit(i) != 0 {
-			T.Add(T, G)
-		}
-	}
-	if T != P {
-		P.Set(T)
+	R0 := &projPoint{}
+	R0.Set(&P.c.null) // Initialize to identity element (0,1)
+	R1 := &projPoint{}
+	R1.Set(G)
+	// R0 and R1 are swapped when the bit differs from the previous one, so
+	// that the ladder step always adds into R1 and doubles R0
+	a := []*mod.Int{&R0.X, &R0.Y, &R0.Z}
+	b := []*mod.Int{&R1.X, &R1.Y, &R1.Z}
+	var swap uint
+	for i := P.c.ladderBits(&v) - 1; i >= 0; i-- {
+		bit := v.Bit(i)
+		condSwap(bit^swap, a, b)
+		swap = bit
+		R1.Add(R0, R1)
+		R0.double()
 	}
+	condSwap(swap, a, b)
+	P.Set(R0)
 	return P
 }
 
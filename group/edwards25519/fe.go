@@ -133,7 +133,8 @@ func feFromBytes(dst *fieldElement, src []byte) {
 	dst[9] = int32(h9)
 }
 
-// feToBytes marshals h to s.
+// feToBytes marshals h to s. h itself is left unchanged, so that the
+// canonical limbs computed here do not leak into later additions.
 // Preconditions:
 //   |h| bounded by 1.1*2^25,1.1*2^24,1.1*2^25,1.1*2^24,etc.
 //
@@ -156,8 +157,9 @@ func feFromBytes(dst *fieldElement, src []byte) {
 //
 //   Have q+2^(-255)x = 2^(-255)(h + 19 2^(-25) h9 + 2^(-1))
 //   so floor(2^(-255)(h + 19 2^(-25) h9 + 2^(-1))) = q.
-func feToBytes(s *[32]byte, h *fieldElement) {
+func feToBytes(s *[32]byte, f *fieldElement) {
 	var carry [10]int32
+	h := *f
 
 	q := (19*h[9] + (1 << 24)) >> 25
 	q = (h[0] + q) >> 26
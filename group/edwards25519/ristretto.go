@@ -0,0 +1,437 @@
+package edwards25519
+
+import (
+	"crypto/cipher"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/internal/marshalling"
+)
+
+// This file implements the ristretto255 prime-order group on top of the
+// Ed25519 curve arithmetic, following RFC 9496. Each element of the group is
+// an equivalence class of Edwards points modulo the 8-torsion subgroup, and
+// has a unique 32-byte encoding.
+
+// sqrt(a*d-1), with a=-1
+var sqrtADMinusOne = fieldElement{
+	24849947, 33400850, 43495378, 6347714, 46036536, 32887293, 41837720, 18186727, 66238516, 14525638,
+}
+
+// 1/sqrt(a-d), with a=-1
+var invSqrtAMinusD = fieldElement{
+	6111466, 4156064, 39310137, 12243467, 41204824, 120896, 20826367, 26493656, 6093567, 31568420,
+}
+
+// 1-d^2
+var oneMinusDSq = fieldElement{
+	6275446, 16937061, 44170319, 29780721, 11667076, 7397348, 39186143, 1766194, 42675006, 672202,
+}
+
+// (d-1)^2
+var dMinusOneSq = fieldElement{
+	15551776, 22456977, 53683765, 23429360, 55212328, 10178283, 40474537, 4729243, 61826754, 23438029,
+}
+
+type ristrettoPoint struct {
+	ge extendedGroupElement
+}
+
+func (P *ristrettoPoint) String() string {
+	var b [32]byte
+	P.encode(&b)
+	return hex.EncodeToString(b[:])
+}
+
+func (P *ristrettoPoint) MarshalSize() int {
+	return 32
+}
+
+func (P *ristrettoPoint) MarshalBinary() ([]byte, error) {
+	var b [32]byte
+	P.encode(&b)
+	return b[:], nil
+}
+
+func (P *ristrettoPoint) UnmarshalBinary(b []byte) error {
+	if !P.decode(b) {
+		return errors.New("invalid Ristretto255 point")
+	}
+	return nil
+}
+
+func (P *ristrettoPoint) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *ristrettoPoint) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+// Equal compares two elements without encoding them: (x1,y1) and (x2,y2)
+// represent the same element iff x1·y2 = y1·x2 or y1·y2 = x1·x2.
+func (P *ristrettoPoint) Equal(P2 kyber.Point) bool {
+	Q := &P2.(*ristrettoPoint).ge
+	var a, b [32]byte
+	var t1, t2 fieldElement
+
+	feMul(&t1, &P.ge.X, &Q.Y)
+	feMul(&t2, &P.ge.Y, &Q.X)
+	feToBytes(&a, &t1)
+	feToBytes(&b, &t2)
+	eq1 := subtle.ConstantTimeCompare(a[:], b[:])
+
+	feMul(&t1, &P.ge.Y, &Q.Y)
+	feMul(&t2, &P.ge.X, &Q.X)
+	feToBytes(&a, &t1)
+	feToBytes(&b, &t2)
+	eq2 := subtle.ConstantTimeCompare(a[:], b[:])
+
+	return eq1|eq2 == 1
+}
+
+// Set point to be equal to P2.
+func (P *ristrettoPoint) Set(P2 kyber.Point) kyber.Point {
+	P.ge = P2.(*ristrettoPoint).ge
+	return P
+}
+
+// Clone returns a copy of P.
+func (P *ristrettoPoint) Clone() kyber.Point {
+	return &ristrettoPoint{ge: P.ge}
+}
+
+// Null sets P to the identity element.
+func (P *ristrettoPoint) Null() kyber.Point {
+	P.ge.Zero()
+	return P
+}
+
+// Base sets P to the standard generator, which is the Ed25519 base point.
+func (P *ristrettoPoint) Base() kyber.Point {
+	P.ge = baseext
+	return P
+}
+
+func (P *ristrettoPoint) EmbedLen() int {
+	// Reserve the most-significant 8 bits for pseudo-randomness.
+	// Reserve the least-significant 8 bits for embedded data length,
+	// whose lowest bit must stay clear to get a canonical encoding.
+	return (255 - 8 - 8) / 8
+}
+
+func (P *ristrettoPoint) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	// How many bytes to embed?
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+
+	for {
+		// Pick a random encoding, with optional embedded data. All valid
+		// encodings represent an element of the prime-order group.
+		var b [32]byte
+		rand.XORKeyStream(b[:], b[:])
+		b[31] &= 0x7f
+		b[0] &= 0xfe
+		if data != nil {
+			b[0] = byte(dl) << 1  // Encode length in low 8 bits
+			copy(b[1:1+dl], data) // Copy in data to embed
+		}
+		if P.decode(b[:]) {
+			return P
+		}
+	}
+}
+
+func (P *ristrettoPoint) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+// Data extracts embedded data from a point group element.
+func (P *ristrettoPoint) Data() ([]byte, error) {
+	var b [32]byte
+	P.encode(&b)
+	dl := int(b[0] >> 1) // extract length byte
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[1 : 1+dl], nil
+}
+
+func (P *ristrettoPoint) Add(P1, P2 kyber.Point) kyber.Point {
+	E1 := P1.(*ristrettoPoint)
+	E2 := P2.(*ristrettoPoint)
+
+	var t2 cachedGroupElement
+	var r completedGroupElement
+
+	E2.ge.ToCached(&t2)
+	r.Add(&E1.ge, &t2)
+	r.ToExtended(&P.ge)
+
+	return P
+}
+
+func (P *ristrettoPoint) Sub(P1, P2 kyber.Point) kyber.Point {
+	E1 := P1.(*ristrettoPoint)
+	E2 := P2.(*ristrettoPoint)
+
+	var t2 cachedGroupElement
+	var r completedGroupElement
+
+	E2.ge.ToCached(&t2)
+	r.Sub(&E1.ge, &t2)
+	r.ToExtended(&P.ge)
+
+	return P
+}
+
+// Neg finds the negative of point A.
+func (P *ristrettoPoint) Neg(A kyber.Point) kyber.Point {
+	P.ge.Neg(&A.(*ristrettoPoint).ge)
+	return P
+}
+
+// Mul multiplies point A by scalar s, or the base point if A is nil.
+func (P *ristrettoPoint) Mul(s kyber.Scalar, A kyber.Point) kyber.Point {
+	a := &s.(*scalar).v
+
+	if A == nil {
+		geScalarMultBase(&P.ge, a)
+	} else {
+		geScalarMult(&P.ge, a, &A.(*ristrettoPoint).ge)
+	}
+
+	return P
+}
+
+// SetUniformBytes sets P to the element derived from 64 uniformly random
+// bytes with the one-way map of RFC 9496, section 4.3.4.
+func (P *ristrettoPoint) SetUniformBytes(b []byte) error {
+	if len(b) != 64 {
+		return errors.New("ristretto255: need 64 uniform bytes")
+	}
+	var t1, t2 fieldElement
+	var r1, r2 extendedGroupElement
+	feFromBytes(&t1, b[:32])
+	feFromBytes(&t2, b[32:])
+	r1.elligator(&t1)
+	r2.elligator(&t2)
+
+	var c cachedGroupElement
+	var r completedGroupElement
+	r2.ToCached(&c)
+	r.Add(&r1, &c)
+	r.ToExtended(&P.ge)
+	return nil
+}
+
+// encode implements the encoding of RFC 9496, section 4.3.2.
+func (P *ristrettoPoint) encode(s *[32]byte) {
+	p := &P.ge
+	var u1, u2, t, invsqrt, den1, den2, zInv fieldElement
+	var ix, iy, enchanted, x, y, denInv fieldElement
+
+	feAdd(&u1, &p.Z, &p.Y)
+	feSub(&t, &p.Z, &p.Y)
+	feMul(&u1, &u1, &t)
+	feMul(&u2, &p.X, &p.Y)
+
+	feSquare(&t, &u2)
+	feMul(&t, &t, &u1)
+	var one fieldElement
+	feOne(&one)
+	feSqrtRatio(&invsqrt, &one, &t)
+
+	feMul(&den1, &invsqrt, &u1)
+	feMul(&den2, &invsqrt, &u2)
+	feMul(&zInv, &den1, &den2)
+	feMul(&zInv, &zInv, &p.T)
+
+	feMul(&ix, &p.X, &sqrtM1)
+	feMul(&iy, &p.Y, &sqrtM1)
+	feMul(&enchanted, &den1, &invSqrtAMinusD)
+
+	feMul(&t, &p.T, &zInv)
+	rotate := int32(feIsNegative(&t))
+
+	feCopy(&x, &p.X)
+	feCopy(&y, &p.Y)
+	feCopy(&denInv, &den2)
+	feCMove(&x, &iy, rotate)
+	feCMove(&y, &ix, rotate)
+	feCMove(&denInv, &enchanted, rotate)
+
+	feMul(&t, &x, &zInv)
+	feCNeg(&y, int32(feIsNegative(&t)))
+
+	feSub(&t, &p.Z, &y)
+	feMul(&t, &denInv, &t)
+	feAbs(&t, &t)
+	feToBytes(s, &t)
+}
+
+// decode implements the decoding of RFC 9496, section 4.3.1. It returns false
+// if b is not the canonical encoding of an element.
+func (P *ristrettoPoint) decode(b []byte) bool {
+	if len(b) != 32 {
+		return false
+	}
+	var s fieldElement
+	var check [32]byte
+	feFromBytes(&s, b)
+	feToBytes(&check, &s)
+	if subtle.ConstantTimeCompare(check[:], b) != 1 || feIsNegative(&s) == 1 {
+		return false
+	}
+
+	var one, ss, u1, u2, u2Sq, v, t, invsqrt, denX, denY fieldElement
+	var p extendedGroupElement
+	feOne(&one)
+	feSquare(&ss, &s)
+	feSub(&u1, &one, &ss)
+	feAdd(&u2, &one, &ss)
+	feSquare(&u2Sq, &u2)
+
+	// v = -(d·u1²) - u2²
+	feSquare(&v, &u1)
+	feMul(&v, &v, &d)
+	feNeg(&v, &v)
+	feSub(&v, &v, &u2Sq)
+
+	feMul(&t, &v, &u2Sq)
+	wasSquare := feSqrtRatio(&invsqrt, &one, &t)
+
+	feMul(&denX, &invsqrt, &u2)
+	feMul(&denY, &invsqrt, &denX)
+	feMul(&denY, &denY, &v)
+
+	feAdd(&p.X, &s, &s)
+	feMul(&p.X, &p.X, &denX)
+	feAbs(&p.X, &p.X)
+	feMul(&p.Y, &u1, &denY)
+	feOne(&p.Z)
+	feMul(&p.T, &p.X, &p.Y)
+
+	if wasSquare == 0 || feIsNegative(&p.T) == 1 || feIsNonZero(&p.Y) == 0 {
+		return false
+	}
+	P.ge = p
+	return true
+}
+
+// elligator sets p to the image of the field element t under the Elligator 2
+// map of RFC 9496, section 4.3.4.
+func (p *extendedGroupElement) elligator(t *fieldElement) {
+	var one, minusOne, r, u, v, tmp, s, sPrime, c, n fieldElement
+	feOne(&one)
+	feNeg(&minusOne, &one)
+
+	feSquare(&r, t)
+	feMul(&r, &r, &sqrtM1)
+
+	feAdd(&u, &r, &one)
+	feMul(&u, &u, &oneMinusDSq)
+
+	// v = (-1 - r·d)·(r + d)
+	feMul(&v, &r, &d)
+	feSub(&v, &minusOne, &v)
+	feAdd(&tmp, &r, &d)
+	feMul(&v, &v, &tmp)
+
+	wasSquare := feSqrtRatio(&s, &u, &v)
+	feMul(&sPrime, &s, t)
+	feAbs(&sPrime, &sPrime)
+	feNeg(&sPrime, &sPrime)
+	feCMove(&s, &sPrime, 1-wasSquare)
+	feCopy(&c, &r)
+	feCMove(&c, &minusOne, wasSquare)
+
+	// n = c·(r - 1)·(d - 1)² - v
+	feSub(&n, &r, &one)
+	feMul(&n, &n, &c)
+	feMul(&n, &n, &dMinusOneSq)
+	feSub(&n, &n, &v)
+
+	var w0, w1, w2, w3 fieldElement
+	feAdd(&w0, &s, &s)
+	feMul(&w0, &w0, &v)
+	feMul(&w1, &n, &sqrtADMinusOne)
+	feSquare(&tmp, &s)
+	feSub(&w2, &one, &tmp)
+	feAdd(&w3, &one, &tmp)
+
+	feMul(&p.X, &w0, &w3)
+	feMul(&p.Y, &w2, &w1)
+	feMul(&p.Z, &w1, &w3)
+	feMul(&p.T, &w0, &w2)
+}
+
+// feSqrtRatio sets r to the non-negative square root of u/v if there is one,
+// and to the non-negative square root of sqrt(-1)·u/v otherwise. It returns 1
+// if u/v was square and 0 otherwise. See RFC 9496, section 4.2.
+func feSqrtRatio(r, u, v *fieldElement) int32 {
+	var v3, v7, check, t, uNeg, uNegI, rPrime fieldElement
+
+	feSquare(&v3, v)
+	feMul(&v3, &v3, v) // v3 = v^3
+	feSquare(&v7, &v3)
+	feMul(&v7, &v7, v) // v7 = v^7
+
+	feMul(&t, u, &v7)
+	fePow22523(&t, &t) // t = (uv^7)^((q-5)/8)
+	feMul(&t, &t, u)
+	feMul(&t, &t, &v3) // t = uv^3(uv^7)^((q-5)/8)
+
+	feSquare(&check, &t)
+	feMul(&check, &check, v)
+
+	feNeg(&uNeg, u)
+	feMul(&uNegI, &uNeg, &sqrtM1)
+
+	correctSign := feEqual(&check, u)
+	flippedSign := feEqual(&check, &uNeg)
+	flippedSignI := feEqual(&check, &uNegI)
+
+	feMul(&rPrime, &t, &sqrtM1)
+	feCMove(&t, &rPrime, flippedSign|flippedSignI)
+	feAbs(r, &t)
+
+	return correctSign | flippedSign
+}
+
+// feEqual returns 1 if a and b represent the same field element, 0 otherwise.
+func feEqual(a, b *fieldElement) int32 {
+	var t fieldElement
+	feSub(&t, a, b)
+	return 1 - feIsNonZero(&t)
+}
+
+// feCNeg replaces f with -f if b == 1.
+func feCNeg(f *fieldElement, b int32) {
+	var neg fieldElement
+	feNeg(&neg, f)
+	feCMove(f, &neg, b)
+}
+
+// feAbs sets h to the non-negative one of f and -f.
+func feAbs(h, f *fieldElement) {
+	feCopy(h, f)
+	feCNeg(h, int32(feIsNegative(f)))
+}
+
+// hashToRistretto derives an element from msg by hashing it with SHA-512 and
+// applying the one-way map of RFC 9496.
+func hashToRistretto(msg []byte) *ristrettoPoint {
+	h := sha512.Sum512(msg)
+	P := new(ristrettoPoint)
+	if err := P.SetUniformBytes(h[:]); err != nil {
+		panic(err)
+	}
+	return P
+}
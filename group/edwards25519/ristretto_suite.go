@@ -0,0 +1,115 @@
+package edwards25519
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"reflect"
+
+	"github.com/dedis/fixbuf"
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/internal/marshalling"
+	"github.com/dedis/kyber/util/random"
+	"github.com/dedis/kyber/xof/blake2xb"
+)
+
+// Ristretto255 represents the ristretto255 prime-order group built on top of
+// the Ed25519 curve. It shares its scalars with Curve, but points have no
+// cofactor and use the Ristretto encoding instead of the Edwards one.
+type Ristretto255 struct {
+}
+
+// Return the name of the group, "Ristretto255".
+func (r *Ristretto255) String() string {
+	return "Ristretto255"
+}
+
+// ScalarLen returns 32, the size in bytes of an encoded Scalar.
+func (r *Ristretto255) ScalarLen() int {
+	return 32
+}
+
+// Scalar creates a new Scalar modulo the order of the group.
+func (r *Ristretto255) Scalar() kyber.Scalar {
+	return &scalar{}
+}
+
+// PointLen returns 32, the size in bytes of an encoded Point.
+func (r *Ristretto255) PointLen() int {
+	return 32
+}
+
+// Point creates a new Point of the ristretto255 group.
+func (r *Ristretto255) Point() kyber.Point {
+	return new(ristrettoPoint)
+}
+
+// NewKey returns a uniformly random scalar. Unlike for Ed25519, no clamping
+// is needed since the group has prime order.
+func (r *Ristretto255) NewKey(stream cipher.Stream) kyber.Scalar {
+	return r.Scalar().Pick(stream)
+}
+
+// Hash2Point deterministically maps msg to a point of the group whose discrete
+// logarithm is unknown. msg is hashed with SHA-512 and the digest is mapped
+// with the Elligator-based one-way map of RFC 9496.
+func (r *Ristretto255) Hash2Point(msg []byte) kyber.Point {
+	return hashToRistretto(msg)
+}
+
+// SuiteRistretto255 implements some basic functionalities such as Group,
+// HashFactory, and XOFFactory for the ristretto255 group.
+type SuiteRistretto255 struct {
+	Ristretto255
+	r cipher.Stream
+}
+
+// Hash returns a newly instanciated sha256 hash function.
+func (s *SuiteRistretto255) Hash() hash.Hash {
+	return sha256.New()
+}
+
+// XOF returns an XOF which is implemented via the Blake2b hash.
+func (s *SuiteRistretto255) XOF(key []byte) kyber.XOF {
+	return blake2xb.New(key)
+}
+
+func (s *SuiteRistretto255) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *SuiteRistretto255) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs)
+}
+
+// New implements the kyber.Encoding interface
+func (s *SuiteRistretto255) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *SuiteRistretto255) RandomStream() cipher.Stream {
+	if s.r != nil {
+		return s.r
+	}
+	return random.New()
+}
+
+// NewBlakeSHA256Ristretto255 returns a cipher suite based on package
+// github.com/dedis/kyber/xof/blake2xb, SHA-256, and the ristretto255 group.
+// It produces cryptographically random numbers via package crypto/rand.
+func NewBlakeSHA256Ristretto255() *SuiteRistretto255 {
+	suite := new(SuiteRistretto255)
+	return suite
+}
+
+// NewBlakeSHA256Ristretto255WithRand returns a cipher suite based on package
+// github.com/dedis/kyber/xof/blake2xb, SHA-256, and the ristretto255 group.
+// It produces cryptographically random numbers via the provided stream r.
+func NewBlakeSHA256Ristretto255WithRand(r cipher.Stream) *SuiteRistretto255 {
+	suite := new(SuiteRistretto255)
+	suite.r = r
+	return suite
+}
@@ -0,0 +1,162 @@
+package edwards25519
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/util/random"
+	"github.com/dedis/kyber/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRistrettoSuite(t *testing.T) { test.SuiteTest(t, NewBlakeSHA256Ristretto255()) }
+
+// Encodings of the multiples 0·B to 15·B of the generator, from RFC 9496,
+// appendix A.1.
+var ristrettoMultiples = []string{
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+	"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+	"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+	"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	"e882b131016b52c1d3337080187cf768423efccbb517bb495ab812c4160ff44e",
+	"f64746d3c92b13050ed8d80236a7f0007c3b3f962f5ba793d19a601ebb1df403",
+	"44f53520926ec81fbd5a387845beb7df85a96a24ece18738bdcfa6a7822a176d",
+	"903293d8f2287ebe10e2374dc1a53e0bc887e592699f02d077d5263cdd55601c",
+	"02622ace8f7303a31cafc63f8fc48fdc16e1c8c8d234b2f0d6685282a9076031",
+	"20706fd788b2720a1ed2a5dad4952b01f413bcf0e7564de8cdc816689e2db95f",
+	"bce83f8ba5dd2fa572864c24ba1810f9522bc6004afe95877ac73241cafdab42",
+	"e4549ee16b9aa03099ca208c67adafcafa4c3f3e4e5303de6026e3ca8ff84460",
+	"aa52e000df2e16f55fb1032fc33bc42742dad6bd5a8fc0be0167436c5948501f",
+	"46376b80f409b29dc2b5f6f0c52591990896e5716f41477cd30085ab7f10301e",
+	"e0c418f7c8d9c4cdd7395b93ea124f3ad99021bb681dfc3302a9d99a2e53e64e",
+}
+
+func TestRistrettoMultiples(t *testing.T) {
+	g := new(Ristretto255)
+	B := g.Point().Base()
+	P := g.Point().Null()
+	for i, enc := range ristrettoMultiples {
+		b, err := P.MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, enc, hex.EncodeToString(b), "multiple %d", i)
+
+		Q := g.Point()
+		require.Nil(t, Q.UnmarshalBinary(b))
+		require.True(t, Q.Equal(P))
+
+		// compare with scalar multiplication
+		R := g.Point().Mul(g.Scalar().SetInt64(int64(i)), nil)
+		require.True(t, R.Equal(P))
+
+		P.Add(P, B)
+	}
+}
+
+func TestRistrettoInvalidEncodings(t *testing.T) {
+	bad := []string{
+		// non-canonical field encodings
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// negative field elements
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// wrong length
+		"00",
+	}
+	g := new(Ristretto255)
+	for _, enc := range bad {
+		b, err := hex.DecodeString(enc)
+		require.Nil(t, err)
+		require.Error(t, g.Point().UnmarshalBinary(b), enc)
+	}
+
+	// About half of the even field elements are not valid encodings.
+	var b [32]byte
+	invalid := 0
+	for i := 0; i < 64; i++ {
+		b[0] = byte(2 * i)
+		if g.Point().UnmarshalBinary(b[:]) != nil {
+			invalid++
+		}
+	}
+	require.True(t, invalid > 0 && invalid < 64)
+}
+
+func TestRistrettoTorsion(t *testing.T) {
+	// Adding an 8-torsion point does not change the element, nor its encoding.
+	var torsion extendedGroupElement
+	var y [32]byte
+	y[0] = 0xec // y = -1, a point of order 2
+	for i := 1; i < 31; i++ {
+		y[i] = 0xff
+	}
+	y[31] = 0x7f
+	require.True(t, torsion.FromBytes(y[:]))
+
+	g := new(Ristretto255)
+	P := g.Point().Pick(random.New())
+	Q := P.Clone().(*ristrettoPoint)
+	var c cachedGroupElement
+	var r completedGroupElement
+	torsion.ToCached(&c)
+	r.Add(&Q.ge, &c)
+	r.ToExtended(&Q.ge)
+
+	require.True(t, P.Equal(Q))
+	bp, _ := P.MarshalBinary()
+	bq, _ := Q.MarshalBinary()
+	require.Equal(t, bp, bq)
+}
+
+func TestRistrettoHash2Point(t *testing.T) {
+	g := new(Ristretto255)
+	P1 := g.Hash2Point([]byte("kyber"))
+	P2 := g.Hash2Point([]byte("kyber"))
+	P3 := g.Hash2Point([]byte("kyber!"))
+	require.True(t, P1.Equal(P2))
+	require.False(t, P1.Equal(P3))
+	require.False(t, P1.Equal(g.Point().Null()))
+
+	// The result is a valid, canonically encoded element.
+	b, err := P1.MarshalBinary()
+	require.Nil(t, err)
+	Q := g.Point()
+	require.Nil(t, Q.UnmarshalBinary(b))
+	require.True(t, Q.Equal(P1))
+
+	require.Error(t, new(ristrettoPoint).SetUniformBytes(make([]byte, 32)))
+
+	// RFC 9496, appendix A.3: the one-way map applied to the SHA-512 digests
+	// of the messages.
+	for _, v := range []struct{ msg, enc string }{
+		{"Ristretto is traditionally a short shot of espresso coffee",
+			"3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46"},
+		{"made with the normal amount of ground coffee but extracted with",
+			"f26e5b6f7d362d2d2a94c5d0e7602cb4773c95a2e5c31a64f133189fa76ed61b"},
+		{"about half the amount of water in the same amount of time",
+			"006ccd2a9e6867e6a2c5cea83d3302cc9de128dd2a9a57dd8ee7b9d7ffe02826"},
+		{"by using a finer grind.",
+			"f8f0c87cf237953c5890aec3998169005dae3eca1fbb04548c635953c817f92a"},
+		{"This produces a concentrated shot of coffee per volume.",
+			"ae81e7dedf20a497e10c304a765c1767a42d6e06029758d2d7e8ef7cc4c41179"},
+		{"Just pulling a normal shot short will produce a weaker shot",
+			"e2705652ff9f5e44d3e841bf1c251cf7dddb77d140870d1ab2ed64f1a9ce8628"},
+		{"and is not a Ristretto as some believe.",
+			"80bd07262511cdde4863f8a7434cef696750681cb9510eea557088f76d9e5065"},
+	} {
+		b, err := g.Hash2Point([]byte(v.msg)).MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, v.enc, hex.EncodeToString(b), v.msg)
+	}
+}
+
+func TestRistrettoEmbed(t *testing.T) {
+	g := new(Ristretto255)
+	data := []byte("ristretto255 embedded data")
+	P := g.Point().Embed(data, random.New())
+	d, err := P.Data()
+	require.Nil(t, err)
+	require.Equal(t, data, d)
+}
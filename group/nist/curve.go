@@ -160,13 +160,20 @@ func (p *curvePoint) Neg(a kyber.Point) kyber.Point {
 	return p.Mul(s, a).(*curvePoint)
 }
 
+// Mul uses the constant time scalar multiplication of Go's elliptic curve
+// library. The scalar is passed with a fixed length, so that its leading
+// zero bytes do not change the work done.
 func (p *curvePoint) Mul(s kyber.Scalar, b kyber.Point) kyber.Point {
 	cs := s.(*mod.Int)
+	k := cs.V.Bytes()
+	if l := p.c.ScalarLen(); len(k) < l {
+		k = append(make([]byte, l-len(k)), k...)
+	}
 	if b != nil {
 		cb := b.(*curvePoint)
-		p.x, p.y = p.c.ScalarMult(cb.x, cb.y, cs.V.Bytes())
+		p.x, p.y = p.c.ScalarMult(cb.x, cb.y, k)
 	} else {
-		p.x, p.y = p.c.ScalarBaseMult(cs.V.Bytes())
+		p.x, p.y = p.c.ScalarBaseMult(k)
 	}
 	return p
 }
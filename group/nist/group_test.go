@@ -3,9 +3,11 @@
 package nist
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/dedis/kyber/util/test"
+	"github.com/stretchr/testify/require"
 )
 
 var testQR512 = NewBlakeSHA256QR512()
@@ -46,3 +48,36 @@ func BenchmarkPointBaseMul(b *testing.B) { benchP256.PointBaseMul(b.N) }
 func BenchmarkPointPick(b *testing.B)    { benchP256.PointPick(b.N) }
 func BenchmarkPointEncode(b *testing.B)  { benchP256.PointEncode(b.N) }
 func BenchmarkPointDecode(b *testing.B)  { benchP256.PointDecode(b.N) }
+
+var testP521 = NewBlakeSHA512P521()
+
+func TestP521(t *testing.T) { test.SuiteTest(t, testP521) }
+
+// COUNT = 0 of the P-521 ECC CDH primitive test vectors of the NIST CAVP.
+func TestP521CDH(t *testing.T) {
+	unhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.Nil(t, err)
+		return b
+	}
+	qCAVS := "04" +
+		"00685a48e86c79f0f0875f7bc18d25eb5fc8c0b07e5da4f4370f3a9490340854334b1e1b87fa395464c60626124a4e70d0f785601d37c09870ebf176666877a2046d" +
+		"01ba52c56fc8776d9e8f5db4f0cc27636d0b741bbe05400697942e80b739884a83bde99e0f6716939e632bc8986fa18dccd443a348b6c3e522497955a4f3c302f676"
+	dIUT := "017eecc07ab4b329068fba65e56a1f8890aa935e57134ae0ffcce802735151f4eac6564f6ee9974c5e6887a1fefee5743ae2241bfeb95d5ce31ddcb6f9edb4d6fc47"
+	qIUT := "04" +
+		"00602f9d0cf9e526b29e22381c203c48a886c2b0673033366314f1ffbcba240ba42f4ef38a76174635f91e6b4ed34275eb01c8467d05ca80315bf1a7bbd945f550a5" +
+		"01b7c85f26f5d4b2d7355cf6b02117659943762b6d1db5ab4f1dbc44ce7b2946eb6c7de342962893fd387d1b73d7a8672d1f236961170b7eb3579953ee5cdc88cd2d"
+	zIUT := "005fc70477c3e63bc3954bd0df3ea0d1f41ee21746ed95fc5e1fdf90930d5e136672d72cc770742d1711c3c3a4c334a0ad9759436a4d3c5bf6e74b9578fac148c831"
+
+	d := testP521.Scalar().SetBytes(unhex(dIUT))
+	Q, err := testP521.Point().Mul(d, nil).MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, qIUT, hex.EncodeToString(Q))
+
+	P := testP521.Point()
+	require.Nil(t, P.UnmarshalBinary(unhex(qCAVS)))
+	Z, err := testP521.Point().Mul(d, P).MarshalBinary()
+	require.Nil(t, err)
+	coordLen := testP521.coordLen()
+	require.Equal(t, zIUT, hex.EncodeToString(Z[1:1+coordLen]))
+}
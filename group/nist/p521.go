@@ -0,0 +1,35 @@
+// +build vartime
+
+package nist
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// P521 implements the kyber.Group interface
+// for the NIST P-521 elliptic curve,
+// based on Go's native elliptic curve library.
+type p521 struct {
+	curve
+}
+
+func (curve *p521) String() string {
+	return "P521"
+}
+
+// Modular square root for P-521 curve. Since p = 2^521-1 is 3 mod 4,
+// a square root of c is c^((p+1)/4) = c^(2^519).
+func (curve *p521) sqrt(c *big.Int) *big.Int {
+	e := new(big.Int)
+	e.SetBit(e, 519, 1)
+	return new(big.Int).Exp(c, e, curve.p.P)
+}
+
+// Initialize standard Curve instances
+func (c *p521) Init() curve {
+	c.curve.Curve = elliptic.P521()
+	c.p = c.Params()
+	c.curveOps = c
+	return c.curve
+}
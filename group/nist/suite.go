@@ -5,6 +5,7 @@ package nist
 import (
 	"crypto/cipher"
 	"crypto/sha256"
+	"crypto/sha512"
 	"hash"
 	"io"
 	"reflect"
@@ -58,3 +59,45 @@ func NewBlakeSHA256P256() *Suite128 {
 	suite.p256.Init()
 	return suite
 }
+
+// Suite256 is the cipher suite of the NIST P-521 elliptic curve, which
+// offers about 256 bits of security.
+type Suite256 struct {
+	p521
+}
+
+// SHA512 hash function
+func (s *Suite256) Hash() hash.Hash {
+	return sha512.New()
+}
+
+func (s *Suite256) XOF(key []byte) kyber.XOF {
+	return blake2xb.New(key)
+}
+
+func (s *Suite256) RandomStream() cipher.Stream {
+	return random.New()
+}
+
+func (s *Suite256) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs)
+}
+
+func (s *Suite256) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs)
+}
+
+func (s *Suite256) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// NewBlakeSHA512P521 returns a cipher suite based on package
+// github.com/dedis/kyber/xof/blake2xb, SHA-512, and the NIST P-521
+// elliptic curve. It returns random streams from Go's crypto/rand.
+//
+// As with NewBlakeSHA256P256, the scalars are big-endian integers.
+func NewBlakeSHA512P521() *Suite256 {
+	suite := new(Suite256)
+	suite.p521.Init()
+	return suite
+}
@@ -0,0 +1,84 @@
+package bls12381
+
+import (
+	"math/big"
+)
+
+func bigFromBase16(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+// xAbs is the absolute value of the BLS parameter x = -0xd201000000010000
+// that determines the prime and the group order.
+const xAbs uint64 = 0xd201000000010000
+
+// p is a prime over which we form a basic field: (x-1)²(x⁴-x²+1)/3+x.
+var p = bigFromBase16("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+
+// Order is the number of elements in both G₁ and G₂: x⁴-x²+1.
+var Order = bigFromBase16("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+
+// p2 is p, represented as little-endian 64-bit words.
+var p2 = bigToWords(p)
+
+// np is the negative inverse of p, mod 2^64.
+var np = func() uint64 {
+	r := new(big.Int).Lsh(big.NewInt(1), 64)
+	n := new(big.Int).ModInverse(p, r)
+	n.Sub(r, n)
+	return n.Uint64()
+}()
+
+// rN1 is R where R = 2^384 mod p, i.e. the Montgomery encoding of 1.
+var rN1 = func() *gfP {
+	r := new(big.Int).Lsh(big.NewInt(1), 384)
+	r.Mod(r, p)
+	e := gfP(bigToWords(r))
+	return &e
+}()
+
+// r2 is R^2 where R = 2^384 mod p.
+var r2 = func() *gfP {
+	r := new(big.Int).Lsh(big.NewInt(1), 768)
+	r.Mod(r, p)
+	e := gfP(bigToWords(r))
+	return &e
+}()
+
+// pMinus2 is the exponent used to compute inverses in GF(p).
+var pMinus2 = new(big.Int).Sub(p, big.NewInt(2))
+
+// pPlus1Over4 is the exponent used to compute square roots in GF(p), since
+// p = 3 mod 4.
+var pPlus1Over4 = new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2)
+
+// pMinus3Over4 and pMinus1Over2 are the exponents used to compute square
+// roots in GF(p²).
+var pMinus3Over4 = new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(3)), 2)
+var pMinus1Over2 = new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(1)), 1)
+
+// finalExp is (p⁶+1)/Order, the hard part of the final exponentiation once
+// the easy part p⁶-1 has been applied.
+var finalExp = func() *big.Int {
+	p6 := new(big.Int).Exp(p, big.NewInt(6), nil)
+	p6.Add(p6, big.NewInt(1))
+	q, m := new(big.Int).DivMod(p6, Order, new(big.Int))
+	if m.Sign() != 0 {
+		panic("bls12-381: Order does not divide p⁶+1")
+	}
+	return q
+}()
+
+func bigToWords(n *big.Int) [6]uint64 {
+	var w [6]uint64
+	b := make([]byte, 48)
+	nb := n.Bytes()
+	copy(b[48-len(nb):], nb)
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 8; j++ {
+			w[5-i] = w[5-i]<<8 | uint64(b[8*i+j])
+		}
+	}
+	return w
+}
@@ -0,0 +1,211 @@
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// gfP is an element of GF(p) kept in Montgomery form, represented as
+// little-endian 64-bit words.
+type gfP [6]uint64
+
+func newGFp(x int64) (out *gfP) {
+	if x >= 0 {
+		out = &gfP{uint64(x)}
+	} else {
+		out = &gfP{uint64(-x)}
+		gfpNeg(out, out)
+	}
+
+	montEncode(out, out)
+	return out
+}
+
+func (e *gfP) String() string {
+	return fmt.Sprintf("%16.16x%16.16x%16.16x%16.16x%16.16x%16.16x", e[5], e[4], e[3], e[2], e[1], e[0])
+}
+
+func (e *gfP) Set(f *gfP) {
+	*e = *f
+}
+
+func (e *gfP) IsZero() bool {
+	return *e == gfP{0}
+}
+
+// Exp sets e = f^power.
+func (e *gfP) Exp(f *gfP, power *big.Int) {
+	sum, t := &gfP{}, &gfP{}
+	sum.Set(rN1)
+	t.Set(f)
+
+	for i := power.BitLen() - 1; i >= 0; i-- {
+		gfpMul(sum, sum, sum)
+		if power.Bit(i) != 0 {
+			gfpMul(sum, sum, t)
+		}
+	}
+	e.Set(sum)
+}
+
+func (e *gfP) Invert(f *gfP) {
+	e.Exp(f, pMinus2)
+}
+
+// Sqrt sets e to a square root of f and returns true if f is a quadratic
+// residue. Otherwise e is left unchanged and false is returned.
+func (e *gfP) Sqrt(f *gfP) bool {
+	s, t := &gfP{}, &gfP{}
+	s.Exp(f, pPlus1Over4)
+	gfpMul(t, s, s)
+	if *t != *f {
+		return false
+	}
+	e.Set(s)
+	return true
+}
+
+// Marshal writes the big-endian encoding of e to out. e must not be in
+// Montgomery form.
+func (e *gfP) Marshal(out []byte) {
+	for w := uint(0); w < 6; w++ {
+		for b := uint(0); b < 8; b++ {
+			out[8*w+b] = byte(e[5-w] >> (56 - 8*b))
+		}
+	}
+}
+
+// Unmarshal reads the big-endian encoding of an element of GF(p) from in. The
+// result is not in Montgomery form.
+func (e *gfP) Unmarshal(in []byte) error {
+	*e = gfP{0}
+	for w := uint(0); w < 6; w++ {
+		for b := uint(0); b < 8; b++ {
+			e[5-w] += uint64(in[8*w+b]) << (56 - 8*b)
+		}
+	}
+	// Check that e is a canonical encoding, i.e. e < p.
+	for i := 5; i >= 0; i-- {
+		if e[i] < p2[i] {
+			return nil
+		} else if e[i] > p2[i] {
+			break
+		}
+	}
+	return errors.New("bls12-381: coordinate exceeds modulus")
+}
+
+// greater returns true if a > b when both are interpreted as integers. The
+// arguments must not be in Montgomery form.
+func (e *gfP) greater(f *gfP) bool {
+	for i := 5; i >= 0; i-- {
+		if e[i] != f[i] {
+			return e[i] > f[i]
+		}
+	}
+	return false
+}
+
+func montEncode(c, a *gfP) { gfpMul(c, a, r2) }
+func montDecode(c, a *gfP) { gfpMul(c, a, &gfP{1}) }
+
+// gfpCarry subtracts p from a if a >= p, where head is the carry bit above
+// the most significant word of a.
+func gfpCarry(a *gfP, head uint64) {
+	b := &gfP{}
+
+	var borrow uint64
+	for i, pi := range p2 {
+		b[i], borrow = bits.Sub64(a[i], pi, borrow)
+	}
+	_, borrow = bits.Sub64(head, 0, borrow)
+
+	// If b is negative, then return a.
+	// Else return b.
+	mask := -borrow
+	for i := 0; i < 6; i++ {
+		a[i] = (a[i] & mask) | (b[i] &^ mask)
+	}
+}
+
+func gfpNeg(c, a *gfP) {
+	var borrow uint64
+	for i, pi := range p2 {
+		c[i], borrow = bits.Sub64(pi, a[i], borrow)
+	}
+	gfpCarry(c, 0)
+}
+
+func gfpAdd(c, a, b *gfP) {
+	var carry uint64
+	for i := 0; i < 6; i++ {
+		c[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	gfpCarry(c, carry)
+}
+
+func gfpSub(c, a, b *gfP) {
+	t := &gfP{}
+
+	var borrow uint64
+	for i, pi := range p2 {
+		t[i], borrow = bits.Sub64(pi, b[i], borrow)
+	}
+
+	var carry uint64
+	for i := 0; i < 6; i++ {
+		c[i], carry = bits.Add64(a[i], t[i], carry)
+	}
+	gfpCarry(c, carry)
+}
+
+// gfpMul computes the Montgomery product c = a·b·R⁻¹ mod p using the CIOS
+// method.
+func gfpMul(c, a, b *gfP) {
+	var t [8]uint64
+
+	for i := 0; i < 6; i++ {
+		// t += a·b[i]
+		var carry uint64
+		for j := 0; j < 6; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			var cc uint64
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j], carry = lo, hi
+		}
+		var cc uint64
+		t[6], cc = bits.Add64(t[6], carry, 0)
+		t[7] = cc
+
+		// t = (t + m·p) / 2^64
+		m := t[0] * np
+		hi, lo := bits.Mul64(m, p2[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		carry = hi + cc
+		for j := 1; j < 6; j++ {
+			hi, lo = bits.Mul64(m, p2[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j-1], carry = lo, hi
+		}
+		t[5], cc = bits.Add64(t[6], carry, 0)
+		t[6] = t[7] + cc
+	}
+
+	*c = gfP{t[0], t[1], t[2], t[3], t[4], t[5]}
+	gfpCarry(c, t[6])
+}
+
+// newGFpFromBase16 returns the Montgomery encoding of the hex integer s.
+func newGFpFromBase16(s string) *gfP {
+	out := gfP(bigToWords(bigFromBase16(s)))
+	montEncode(&out, &out)
+	return &out
+}
@@ -0,0 +1,206 @@
+package bls12381
+
+import (
+	"math/big"
+)
+
+// For details of the algorithms used, see "Multiplication and Squaring on
+// Pairing-Friendly Fields, Devegili et al.
+// http://eprint.iacr.org/2006/471.pdf.
+
+// gfP2 implements a field of size p² as a quadratic extension of the base field
+// where i²=-1.
+type gfP2 struct {
+	x, y gfP // value is xi+y.
+}
+
+func gfP2Decode(in *gfP2) *gfP2 {
+	out := &gfP2{}
+	montDecode(&out.x, &in.x)
+	montDecode(&out.y, &in.y)
+	return out
+}
+
+func (e *gfP2) String() string {
+	return "(" + e.x.String() + ", " + e.y.String() + ")"
+}
+
+func (e *gfP2) Set(a *gfP2) *gfP2 {
+	e.x.Set(&a.x)
+	e.y.Set(&a.y)
+	return e
+}
+
+func (e *gfP2) SetZero() *gfP2 {
+	e.x = gfP{0}
+	e.y = gfP{0}
+	return e
+}
+
+func (e *gfP2) SetOne() *gfP2 {
+	e.x = gfP{0}
+	e.y = *newGFp(1)
+	return e
+}
+
+func (e *gfP2) IsZero() bool {
+	zero := gfP{0}
+	return e.x == zero && e.y == zero
+}
+
+func (e *gfP2) IsOne() bool {
+	zero, one := gfP{0}, *newGFp(1)
+	return e.x == zero && e.y == one
+}
+
+func (e *gfP2) Conjugate(a *gfP2) *gfP2 {
+	e.y.Set(&a.y)
+	gfpNeg(&e.x, &a.x)
+	return e
+}
+
+func (e *gfP2) Neg(a *gfP2) *gfP2 {
+	gfpNeg(&e.x, &a.x)
+	gfpNeg(&e.y, &a.y)
+	return e
+}
+
+func (e *gfP2) Add(a, b *gfP2) *gfP2 {
+	gfpAdd(&e.x, &a.x, &b.x)
+	gfpAdd(&e.y, &a.y, &b.y)
+	return e
+}
+
+func (e *gfP2) Sub(a, b *gfP2) *gfP2 {
+	gfpSub(&e.x, &a.x, &b.x)
+	gfpSub(&e.y, &a.y, &b.y)
+	return e
+}
+
+// See "Multiplication and Squaring in Pairing-Friendly Fields",
+// http://eprint.iacr.org/2006/471.pdf
+func (e *gfP2) Mul(a, b *gfP2) *gfP2 {
+	tx, t := &gfP{}, &gfP{}
+	gfpMul(tx, &a.x, &b.y)
+	gfpMul(t, &b.x, &a.y)
+	gfpAdd(tx, tx, t)
+
+	ty := &gfP{}
+	gfpMul(ty, &a.y, &b.y)
+	gfpMul(t, &a.x, &b.x)
+	gfpSub(ty, ty, t)
+
+	e.x.Set(tx)
+	e.y.Set(ty)
+	return e
+}
+
+func (e *gfP2) MulScalar(a *gfP2, b *gfP) *gfP2 {
+	gfpMul(&e.x, &a.x, b)
+	gfpMul(&e.y, &a.y, b)
+	return e
+}
+
+// MulXi sets e=ξa where ξ=i+1 and then returns e.
+func (e *gfP2) MulXi(a *gfP2) *gfP2 {
+	// (xi+y)(i+1) = (x+y)i+(y-x)
+	tx := &gfP{}
+	gfpAdd(tx, &a.x, &a.y)
+
+	ty := &gfP{}
+	gfpSub(ty, &a.y, &a.x)
+
+	e.x.Set(tx)
+	e.y.Set(ty)
+	return e
+}
+
+func (e *gfP2) Square(a *gfP2) *gfP2 {
+	// Complex squaring algorithm:
+	// (xi+y)² = (x+y)(y-x) + 2*i*x*y
+	tx, ty := &gfP{}, &gfP{}
+	gfpSub(tx, &a.y, &a.x)
+	gfpAdd(ty, &a.x, &a.y)
+	gfpMul(ty, tx, ty)
+
+	gfpMul(tx, &a.x, &a.y)
+	gfpAdd(tx, tx, tx)
+
+	e.x.Set(tx)
+	e.y.Set(ty)
+	return e
+}
+
+func (e *gfP2) Invert(a *gfP2) *gfP2 {
+	// See "Implementing cryptographic pairings", M. Scott, section 3.2.
+	// ftp://136.206.11.249/pub/crypto/pairings.pdf
+	t1, t2 := &gfP{}, &gfP{}
+	gfpMul(t1, &a.x, &a.x)
+	gfpMul(t2, &a.y, &a.y)
+	gfpAdd(t1, t1, t2)
+
+	inv := &gfP{}
+	inv.Invert(t1)
+
+	gfpNeg(t1, &a.x)
+
+	gfpMul(&e.x, t1, inv)
+	gfpMul(&e.y, &a.y, inv)
+	return e
+}
+
+func (e *gfP2) Exp(a *gfP2, power *big.Int) *gfP2 {
+	sum := (&gfP2{}).SetOne()
+	t := (&gfP2{}).Set(a)
+
+	for i := power.BitLen() - 1; i >= 0; i-- {
+		sum.Square(sum)
+		if power.Bit(i) != 0 {
+			sum.Mul(sum, t)
+		}
+	}
+
+	e.Set(sum)
+	return e
+}
+
+// Sqrt sets e to a square root of a and returns true if a is a quadratic
+// residue. Otherwise e is left unchanged and false is returned.
+func (e *gfP2) Sqrt(a *gfP2) bool {
+	// See algorithm 9 of "Square root computation over even extension
+	// fields", Adj and Rodríguez-Henríquez, https://eprint.iacr.org/2012/685
+	a1 := (&gfP2{}).Exp(a, pMinus3Over4)
+	alpha := (&gfP2{}).Mul(a1, a)
+	x0 := (&gfP2{}).Set(alpha)
+	alpha.Mul(alpha, a1)
+
+	minusOne := (&gfP2{}).SetOne()
+	minusOne.Neg(minusOne)
+
+	x := &gfP2{}
+	if *alpha == *minusOne {
+		// x = i·x0
+		x.x.Set(&x0.y)
+		gfpNeg(&x.y, &x0.x)
+	} else {
+		b := (&gfP2{}).SetOne()
+		b.Add(b, alpha).Exp(b, pMinus1Over2)
+		x.Mul(b, x0)
+	}
+
+	check := (&gfP2{}).Square(x)
+	if *check != *a {
+		return false
+	}
+	e.Set(x)
+	return true
+}
+
+// Clone makes a hard copy of the field
+func (e *gfP2) Clone() gfP2 {
+	n := gfP2{}
+	copy(n.x[:], e.x[:])
+	copy(n.y[:], e.y[:])
+
+	return n
+}
@@ -0,0 +1,119 @@
+package bls12381
+
+// lineFunction returns the line through the twist points with affine
+// coordinates (rx, ry) and slope lambda, evaluated at q and multiplied by ω³
+// so that it has the sparse form (yq·τ)ω + (-λ·xq·τ + λ·rx - ry). The factor
+// ω³ lies in a proper subfield of GF(p¹²) and is killed by the final
+// exponentiation.
+func lineFunction(lambda, rx, ry *gfP2, q *curvePoint) *gfP12 {
+	l := &gfP12{}
+	l.y.z.Mul(lambda, rx).Sub(&l.y.z, ry)
+	l.y.y.MulScalar(lambda, &q.x).Neg(&l.y.y)
+	l.x.y.y.Set(&q.y)
+	return l
+}
+
+// lineFunctionDouble computes the tangent line at (rx, ry) evaluated at q and
+// sets (rx, ry) to twice that point.
+func lineFunctionDouble(rx, ry *gfP2, q *curvePoint) *gfP12 {
+	// λ = 3x²/2y
+	lambda := (&gfP2{}).Square(rx)
+	t := (&gfP2{}).Add(lambda, lambda)
+	lambda.Add(lambda, t)
+	t.Add(ry, ry).Invert(t)
+	lambda.Mul(lambda, t)
+
+	l := lineFunction(lambda, rx, ry, q)
+	lineAdvance(lambda, rx, ry, rx)
+	return l
+}
+
+// lineFunctionAdd computes the line through (rx, ry) and (px, py) evaluated at
+// q and sets (rx, ry) to the sum of both points.
+func lineFunctionAdd(rx, ry, px, py *gfP2, q *curvePoint) *gfP12 {
+	// λ = (py-ry)/(px-rx)
+	lambda := (&gfP2{}).Sub(py, ry)
+	t := (&gfP2{}).Sub(px, rx)
+	t.Invert(t)
+	lambda.Mul(lambda, t)
+
+	l := lineFunction(lambda, rx, ry, q)
+	lineAdvance(lambda, rx, ry, px)
+	return l
+}
+
+// lineAdvance sets (rx, ry) to the sum of (rx, ry) and the point with
+// x-coordinate px on the line of slope lambda through both.
+func lineAdvance(lambda, rx, ry, px *gfP2) {
+	// x₃ = λ²-x₁-x₂, y₃ = λ(x₁-x₃)-y₁
+	x3 := (&gfP2{}).Square(lambda)
+	x3.Sub(x3, rx).Sub(x3, px)
+
+	t := (&gfP2{}).Sub(rx, x3)
+	t.Mul(lambda, t)
+	ry.Sub(t, ry)
+	rx.Set(x3)
+}
+
+// miller implements the Miller loop for calculating the optimal ate pairing.
+// See Algorithm 1 from http://cryptojedi.org/papers/dclxvi-20100714.pdf
+func miller(q *twistPoint, p *curvePoint) *gfP12 {
+	ret := (&gfP12{}).SetOne()
+
+	aAffine := &twistPoint{}
+	aAffine.Set(q)
+	aAffine.MakeAffine()
+
+	bAffine := &curvePoint{}
+	bAffine.Set(p)
+	bAffine.MakeAffine()
+
+	if aAffine.IsInfinity() || bAffine.IsInfinity() {
+		return ret
+	}
+
+	rx := (&gfP2{}).Set(&aAffine.x)
+	ry := (&gfP2{}).Set(&aAffine.y)
+
+	// The loop runs over the bits of |x| below the most significant one.
+	top := 63
+	for xAbs>>uint(top) == 0 {
+		top--
+	}
+	for i := top - 1; i >= 0; i-- {
+		ret.Square(ret)
+		ret.Mul(ret, lineFunctionDouble(rx, ry, bAffine))
+
+		if (xAbs>>uint(i))&1 == 1 {
+			ret.Mul(ret, lineFunctionAdd(rx, ry, &aAffine.x, &aAffine.y, bAffine))
+		}
+	}
+
+	// The BLS parameter x is negative, so the result has to be inverted.
+	// After the final exponentiation the inverse is the conjugate.
+	ret.Conjugate(ret)
+	return ret
+}
+
+// finalExponentiation computes the (p¹²-1)/Order-th power of an element of
+// GF(p¹²) to obtain an element of GT (steps 13-15 of algorithm 1 from
+// http://cryptojedi.org/papers/dclxvi-20100714.pdf)
+func finalExponentiation(in *gfP12) *gfP12 {
+	// The easy part: in^(p⁶-1), where in^(p⁶) is the conjugate of in.
+	t1 := (&gfP12{}).Conjugate(in)
+	inv := (&gfP12{}).Invert(in)
+	t1.Mul(t1, inv)
+
+	// The hard part: t1^((p⁶+1)/Order).
+	return t1.Exp(t1, finalExp)
+}
+
+func optimalAte(a *twistPoint, b *curvePoint) *gfP12 {
+	e := miller(a, b)
+	ret := finalExponentiation(e)
+
+	if a.IsInfinity() || b.IsInfinity() {
+		ret.SetOne()
+	}
+	return ret
+}
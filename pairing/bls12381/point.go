@@ -0,0 +1,623 @@
+package bls12381
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/mod"
+)
+
+// Points of G₁ and G₂ are serialized in the compressed form of the zcash
+// BLS12-381 encoding: the x-coordinate in big-endian order, with the three
+// most significant bits of the first byte used as flags.
+const (
+	flagCompressed = 0x80
+	flagInfinity   = 0x40
+	flagSign       = 0x20
+	flagMask       = flagCompressed | flagInfinity | flagSign
+)
+
+type pointG1 struct {
+	g *curvePoint
+}
+
+func newPointG1() *pointG1 {
+	p := &pointG1{g: &curvePoint{}}
+	return p
+}
+
+func (p *pointG1) Equal(q kyber.Point) bool {
+	x, _ := p.MarshalBinary()
+	y, _ := q.MarshalBinary()
+	return subtle.ConstantTimeCompare(x, y) == 1
+}
+
+func (p *pointG1) Null() kyber.Point {
+	p.g.SetInfinity()
+	return p
+}
+
+func (p *pointG1) Base() kyber.Point {
+	p.g.Set(curveGen)
+	return p
+}
+
+func (p *pointG1) Pick(rand cipher.Stream) kyber.Point {
+	s := mod.NewInt64(0, Order).Pick(rand)
+	p.Base()
+	p.g.Mul(p.g, &s.(*mod.Int).V)
+	return p
+}
+
+func (p *pointG1) Set(q kyber.Point) kyber.Point {
+	x := q.(*pointG1).g
+	p.g.Set(x)
+	return p
+}
+
+// Clone makes a hard copy of the point
+func (p *pointG1) Clone() kyber.Point {
+	q := newPointG1()
+	q.g = p.g.Clone()
+	return q
+}
+
+func (p *pointG1) EmbedLen() int {
+	panic("bls12-381.G1: unsupported operation")
+}
+
+func (p *pointG1) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	panic("bls12-381.G1: unsupported operation")
+}
+
+func (p *pointG1) Data() ([]byte, error) {
+	panic("bls12-381.G1: unsupported operation")
+}
+
+func (p *pointG1) Add(a, b kyber.Point) kyber.Point {
+	x := a.(*pointG1).g
+	y := b.(*pointG1).g
+	p.g.Add(x, y) // p = a + b
+	return p
+}
+
+func (p *pointG1) Sub(a, b kyber.Point) kyber.Point {
+	q := newPointG1()
+	return p.Add(a, q.Neg(b))
+}
+
+func (p *pointG1) Neg(q kyber.Point) kyber.Point {
+	x := q.(*pointG1).g
+	p.g.Neg(x)
+	return p
+}
+
+func (p *pointG1) Mul(s kyber.Scalar, q kyber.Point) kyber.Point {
+	if q == nil {
+		q = newPointG1().Base()
+	}
+	t := s.(*mod.Int).V
+	r := q.(*pointG1).g
+	p.g.Mul(r, &t)
+	return p
+}
+
+func (p *pointG1) MarshalBinary() ([]byte, error) {
+	// Take a copy so that p is not written to, so calls to MarshalBinary
+	// are threadsafe.
+	pgtemp := p.g.Clone()
+	pgtemp.MakeAffine()
+	ret := make([]byte, p.MarshalSize())
+	if pgtemp.IsInfinity() {
+		ret[0] = flagCompressed | flagInfinity
+		return ret, nil
+	}
+	tmp := &gfP{}
+	montDecode(tmp, &pgtemp.x)
+	tmp.Marshal(ret)
+	ret[0] |= flagCompressed
+	if gfpIsLarger(&pgtemp.y) {
+		ret[0] |= flagSign
+	}
+	return ret, nil
+}
+
+func (p *pointG1) MarshalTo(w io.Writer) (int, error) {
+	buf, err := p.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(buf)
+}
+
+func (p *pointG1) UnmarshalBinary(buf []byte) error {
+	if len(buf) < p.MarshalSize() {
+		return errors.New("bls12-381.G1: not enough data")
+	}
+	if p.g == nil {
+		p.g = &curvePoint{}
+	}
+
+	flags := buf[0] & flagMask
+	if flags&flagCompressed == 0 {
+		return errors.New("bls12-381.G1: uncompressed encoding not supported")
+	}
+	in := make([]byte, p.ElementSize())
+	copy(in, buf)
+	in[0] &^= flagMask
+
+	if flags&flagInfinity != 0 {
+		if flags&flagSign != 0 || !isZeroBytes(in) {
+			return errors.New("bls12-381.G1: malformed point")
+		}
+		p.g.SetInfinity()
+		return nil
+	}
+
+	x, y := &gfP{}, &gfP{}
+	if err := x.Unmarshal(in); err != nil {
+		return err
+	}
+	montEncode(x, x)
+
+	// y² = x³+b
+	gfpMul(y, x, x)
+	gfpMul(y, y, x)
+	gfpAdd(y, y, curveB)
+	if !y.Sqrt(y) {
+		return errors.New("bls12-381.G1: malformed point")
+	}
+	if gfpIsLarger(y) != (flags&flagSign != 0) {
+		gfpNeg(y, y)
+	}
+
+	p.g.x, p.g.y = *x, *y
+	p.g.z = *newGFp(1)
+	p.g.t = *newGFp(1)
+
+	if !p.g.IsInSubgroup() {
+		return errors.New("bls12-381.G1: point not in subgroup")
+	}
+	return nil
+}
+
+func (p *pointG1) UnmarshalFrom(r io.Reader) (int, error) {
+	buf := make([]byte, p.MarshalSize())
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return n, err
+	}
+	return n, p.UnmarshalBinary(buf)
+}
+
+func (p *pointG1) MarshalSize() int {
+	return p.ElementSize()
+}
+
+func (p *pointG1) ElementSize() int {
+	return 384 / 8
+}
+
+func (p *pointG1) String() string {
+	return "bls12-381.G1:" + p.g.String()
+}
+
+type pointG2 struct {
+	g *twistPoint
+}
+
+func newPointG2() *pointG2 {
+	p := &pointG2{g: &twistPoint{}}
+	return p
+}
+
+func (p *pointG2) Equal(q kyber.Point) bool {
+	x, _ := p.MarshalBinary()
+	y, _ := q.MarshalBinary()
+	return subtle.ConstantTimeCompare(x, y) == 1
+}
+
+func (p *pointG2) Null() kyber.Point {
+	p.g.SetInfinity()
+	return p
+}
+
+func (p *pointG2) Base() kyber.Point {
+	p.g.Set(twistGen)
+	return p
+}
+
+func (p *pointG2) Pick(rand cipher.Stream) kyber.Point {
+	s := mod.NewInt64(0, Order).Pick(rand)
+	p.Base()
+	p.g.Mul(p.g, &s.(*mod.Int).V)
+	return p
+}
+
+func (p *pointG2) Set(q kyber.Point) kyber.Point {
+	x := q.(*pointG2).g
+	p.g.Set(x)
+	return p
+}
+
+// Clone makes a hard copy of the point
+func (p *pointG2) Clone() kyber.Point {
+	q := newPointG2()
+	q.g = p.g.Clone()
+	return q
+}
+
+func (p *pointG2) EmbedLen() int {
+	panic("bls12-381.G2: unsupported operation")
+}
+
+func (p *pointG2) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	panic("bls12-381.G2: unsupported operation")
+}
+
+func (p *pointG2) Data() ([]byte, error) {
+	panic("bls12-381.G2: unsupported operation")
+}
+
+func (p *pointG2) Add(a, b kyber.Point) kyber.Point {
+	x := a.(*pointG2).g
+	y := b.(*pointG2).g
+	p.g.Add(x, y) // p = a + b
+	return p
+}
+
+func (p *pointG2) Sub(a, b kyber.Point) kyber.Point {
+	q := newPointG2()
+	return p.Add(a, q.Neg(b))
+}
+
+func (p *pointG2) Neg(q kyber.Point) kyber.Point {
+	x := q.(*pointG2).g
+	p.g.Neg(x)
+	return p
+}
+
+func (p *pointG2) Mul(s kyber.Scalar, q kyber.Point) kyber.Point {
+	if q == nil {
+		q = newPointG2().Base()
+	}
+	t := s.(*mod.Int).V
+	r := q.(*pointG2).g
+	p.g.Mul(r, &t)
+	return p
+}
+
+func (p *pointG2) MarshalBinary() ([]byte, error) {
+	// Take a copy so that p is not written to, so calls to MarshalBinary
+	// are threadsafe.
+	pgtemp := p.g.Clone()
+	pgtemp.MakeAffine()
+
+	n := p.ElementSize()
+	ret := make([]byte, p.MarshalSize())
+	if pgtemp.IsInfinity() {
+		ret[0] = flagCompressed | flagInfinity
+		return ret, nil
+	}
+
+	// An element c0+c1·i of GF(p²) is encoded as c1 || c0.
+	temp := &gfP{}
+	montDecode(temp, &pgtemp.x.x)
+	temp.Marshal(ret[0*n:])
+	montDecode(temp, &pgtemp.x.y)
+	temp.Marshal(ret[1*n:])
+	ret[0] |= flagCompressed
+	if gfp2IsLarger(&pgtemp.y) {
+		ret[0] |= flagSign
+	}
+	return ret, nil
+}
+
+func (p *pointG2) MarshalTo(w io.Writer) (int, error) {
+	buf, err := p.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(buf)
+}
+
+func (p *pointG2) UnmarshalBinary(buf []byte) error {
+	n := p.ElementSize()
+	if len(buf) < p.MarshalSize() {
+		return errors.New("bls12-381.G2: not enough data")
+	}
+	if p.g == nil {
+		p.g = &twistPoint{}
+	}
+
+	flags := buf[0] & flagMask
+	if flags&flagCompressed == 0 {
+		return errors.New("bls12-381.G2: uncompressed encoding not supported")
+	}
+	in := make([]byte, p.MarshalSize())
+	copy(in, buf)
+	in[0] &^= flagMask
+
+	if flags&flagInfinity != 0 {
+		if flags&flagSign != 0 || !isZeroBytes(in) {
+			return errors.New("bls12-381.G2: malformed point")
+		}
+		p.g.SetInfinity()
+		return nil
+	}
+
+	x, y := &gfP2{}, &gfP2{}
+	if err := x.x.Unmarshal(in[0*n:]); err != nil {
+		return err
+	}
+	if err := x.y.Unmarshal(in[1*n:]); err != nil {
+		return err
+	}
+	montEncode(&x.x, &x.x)
+	montEncode(&x.y, &x.y)
+
+	// y² = x³+b
+	y.Square(x).Mul(y, x).Add(y, twistB)
+	if !y.Sqrt(y) {
+		return errors.New("bls12-381.G2: malformed point")
+	}
+	if gfp2IsLarger(y) != (flags&flagSign != 0) {
+		y.Neg(y)
+	}
+
+	p.g.x.Set(x)
+	p.g.y.Set(y)
+	p.g.z.SetOne()
+	p.g.t.SetOne()
+
+	if !p.g.IsInSubgroup() {
+		return errors.New("bls12-381.G2: point not in subgroup")
+	}
+	return nil
+}
+
+func (p *pointG2) UnmarshalFrom(r io.Reader) (int, error) {
+	buf := make([]byte, p.MarshalSize())
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return n, err
+	}
+	return n, p.UnmarshalBinary(buf)
+}
+
+func (p *pointG2) MarshalSize() int {
+	return 2 * p.ElementSize()
+}
+
+func (p *pointG2) ElementSize() int {
+	return 384 / 8
+}
+
+func (p *pointG2) String() string {
+	return "bls12-381.G2:" + p.g.String()
+}
+
+// gtGen is the generator of GT, e(g₁, g₂). It is computed on first use.
+var gtGen struct {
+	sync.Once
+	g *gfP12
+}
+
+func gfP12Gen() *gfP12 {
+	gtGen.Do(func() {
+		gtGen.g = optimalAte(twistGen, curveGen)
+	})
+	return gtGen.g
+}
+
+type pointGT struct {
+	g *gfP12
+}
+
+func newPointGT() *pointGT {
+	p := &pointGT{g: &gfP12{}}
+	return p
+}
+
+func (p *pointGT) Equal(q kyber.Point) bool {
+	x, _ := p.MarshalBinary()
+	y, _ := q.MarshalBinary()
+	return subtle.ConstantTimeCompare(x, y) == 1
+}
+
+func (p *pointGT) Null() kyber.Point {
+	p.g.SetOne()
+	return p
+}
+
+func (p *pointGT) Base() kyber.Point {
+	p.g.Set(gfP12Gen())
+	return p
+}
+
+func (p *pointGT) Pick(rand cipher.Stream) kyber.Point {
+	s := mod.NewInt64(0, Order).Pick(rand)
+	p.Base()
+	p.g.Exp(p.g, &s.(*mod.Int).V)
+	return p
+}
+
+func (p *pointGT) Set(q kyber.Point) kyber.Point {
+	x := q.(*pointGT).g
+	p.g.Set(x)
+	return p
+}
+
+// Clone makes a hard copy of the point
+func (p *pointGT) Clone() kyber.Point {
+	q := newPointGT()
+	q.g = p.g.Clone()
+	return q
+}
+
+func (p *pointGT) EmbedLen() int {
+	panic("bls12-381.GT: unsupported operation")
+}
+
+func (p *pointGT) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	panic("bls12-381.GT: unsupported operation")
+}
+
+func (p *pointGT) Data() ([]byte, error) {
+	panic("bls12-381.GT: unsupported operation")
+}
+
+func (p *pointGT) Add(a, b kyber.Point) kyber.Point {
+	x := a.(*pointGT).g
+	y := b.(*pointGT).g
+	p.g.Mul(x, y)
+	return p
+}
+
+func (p *pointGT) Sub(a, b kyber.Point) kyber.Point {
+	q := newPointGT()
+	return p.Add(a, q.Neg(b))
+}
+
+func (p *pointGT) Neg(q kyber.Point) kyber.Point {
+	x := q.(*pointGT).g
+	p.g.Conjugate(x)
+	return p
+}
+
+func (p *pointGT) Mul(s kyber.Scalar, q kyber.Point) kyber.Point {
+	if q == nil {
+		q = newPointGT().Base()
+	}
+	t := s.(*mod.Int).V
+	r := q.(*pointGT).g
+	p.g.Exp(r, &t)
+	return p
+}
+
+func (p *pointGT) MarshalBinary() ([]byte, error) {
+	n := p.ElementSize()
+	ret := make([]byte, p.MarshalSize())
+	temp := &gfP{}
+
+	for i, c := range p.g.coefficients() {
+		montDecode(temp, c)
+		temp.Marshal(ret[i*n:])
+	}
+	return ret, nil
+}
+
+func (p *pointGT) MarshalTo(w io.Writer) (int, error) {
+	buf, err := p.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(buf)
+}
+
+func (p *pointGT) UnmarshalBinary(buf []byte) error {
+	n := p.ElementSize()
+	if len(buf) < p.MarshalSize() {
+		return errors.New("bls12-381.GT: not enough data")
+	}
+
+	g := &gfP12{}
+	for i, c := range g.coefficients() {
+		if err := c.Unmarshal(buf[i*n:]); err != nil {
+			return err
+		}
+		montEncode(c, c)
+	}
+
+	// Elements of GT are exactly those of order dividing Order.
+	t := (&gfP12{}).Exp(g, Order)
+	if !t.IsOne() {
+		return errors.New("bls12-381.GT: element not in subgroup")
+	}
+
+	if p.g == nil {
+		p.g = &gfP12{}
+	}
+	p.g.Set(g)
+	return nil
+}
+
+func (p *pointGT) UnmarshalFrom(r io.Reader) (int, error) {
+	buf := make([]byte, p.MarshalSize())
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return n, err
+	}
+	return n, p.UnmarshalBinary(buf)
+}
+
+func (p *pointGT) MarshalSize() int {
+	return 12 * p.ElementSize()
+}
+
+func (p *pointGT) ElementSize() int {
+	return 384 / 8
+}
+
+func (p *pointGT) String() string {
+	return "bls12-381.GT:" + p.g.String()
+}
+
+func (p *pointGT) Finalize() kyber.Point {
+	buf := finalExponentiation(p.g)
+	p.g.Set(buf)
+	return p
+}
+
+func (p *pointGT) Miller(p1, p2 kyber.Point) kyber.Point {
+	a := p1.(*pointG1).g
+	b := p2.(*pointG2).g
+	p.g.Set(miller(b, a))
+	return p
+}
+
+func (p *pointGT) Pair(p1, p2 kyber.Point) kyber.Point {
+	a := p1.(*pointG1).g
+	b := p2.(*pointG2).g
+	p.g.Set(optimalAte(b, a))
+	return p
+}
+
+// coefficients returns the base field coefficients of e in serialization
+// order.
+func (e *gfP12) coefficients() []*gfP {
+	return []*gfP{
+		&e.x.x.x, &e.x.x.y, &e.x.y.x, &e.x.y.y, &e.x.z.x, &e.x.z.y,
+		&e.y.x.x, &e.y.x.y, &e.y.y.x, &e.y.y.y, &e.y.z.x, &e.y.z.y,
+	}
+}
+
+// gfpIsLarger returns true if y is lexicographically larger than -y. y must
+// be in Montgomery form.
+func gfpIsLarger(y *gfP) bool {
+	a, b := &gfP{}, &gfP{}
+	montDecode(a, y)
+	gfpNeg(b, a)
+	return a.greater(b)
+}
+
+// gfp2IsLarger returns true if y is lexicographically larger than -y, comparing
+// the coefficient of i first. y must be in Montgomery form.
+func gfp2IsLarger(y *gfP2) bool {
+	if !y.x.IsZero() {
+		return gfpIsLarger(&y.x)
+	}
+	return gfpIsLarger(&y.y)
+}
+
+func isZeroBytes(b []byte) bool {
+	var acc byte
+	for _, v := range b {
+		acc |= v
+	}
+	return acc == 0
+}
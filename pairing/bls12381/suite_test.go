@@ -0,0 +1,325 @@
+package bls12381
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dedis/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGFpMul(t *testing.T) {
+	a := bigFromBase16("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	b := bigFromBase16("08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1")
+	want := new(big.Int).Mul(a, b)
+	want.Mod(want, p)
+
+	x, y := gfP(bigToWords(a)), gfP(bigToWords(b))
+	montEncode(&x, &x)
+	montEncode(&y, &y)
+	gfpMul(&x, &x, &y)
+	montDecode(&x, &x)
+	require.Equal(t, gfP(bigToWords(want)), x)
+}
+
+func TestGFpInvertSqrt(t *testing.T) {
+	a := newGFp(12345)
+	inv := &gfP{}
+	inv.Invert(a)
+	gfpMul(inv, inv, a)
+	require.Equal(t, *newGFp(1), *inv)
+
+	sq := &gfP{}
+	gfpMul(sq, a, a)
+	root := &gfP{}
+	require.True(t, root.Sqrt(sq))
+	gfpMul(root, root, root)
+	require.Equal(t, *sq, *root)
+}
+
+func TestGFp2Sqrt(t *testing.T) {
+	a := &gfP2{*newGFp(7), *newGFp(-3)}
+	sq := (&gfP2{}).Square(a)
+	root := &gfP2{}
+	require.True(t, root.Sqrt(sq))
+	root.Square(root)
+	require.Equal(t, *sq, *root)
+
+	// ξ = i+1 is a non-residue in GF(p²).
+	xi := (&gfP2{}).SetOne()
+	xi.MulXi(xi)
+	require.False(t, root.Sqrt(xi))
+}
+
+func TestGenerators(t *testing.T) {
+	require.True(t, curveGen.Clone().IsOnCurve())
+	require.True(t, twistGen.Clone().IsOnCurve())
+	require.True(t, curveGen.IsInSubgroup())
+	require.True(t, twistGen.IsInSubgroup())
+
+	suite := NewSuite()
+	g1, err := suite.G1().Point().Base().MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb", hex.EncodeToString(g1))
+
+	g2, err := suite.G2().Point().Base().MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8", hex.EncodeToString(g2))
+
+	inf, err := suite.G1().Point().Null().MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, byte(0xc0), inf[0])
+}
+
+func TestScalarMarshal(t *testing.T) {
+	suite := NewSuite()
+	a := suite.G1().Scalar().Pick(random.New())
+	b := suite.G1().Scalar()
+	am, err := a.MarshalBinary()
+	require.Nil(t, err)
+	require.Nil(t, b.UnmarshalBinary(am))
+	require.True(t, a.Equal(b))
+}
+
+func TestG1Marshal(t *testing.T) {
+	suite := NewSuite()
+	for _, pa := range []interface {
+		MarshalBinary() ([]byte, error)
+	}{
+		suite.G1().Point().Pick(random.New()),
+		suite.G1().Point().Pick(random.New()),
+		suite.G1().Point().Null(),
+	} {
+		ma, err := pa.MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, suite.G1().PointLen(), len(ma))
+
+		pb := suite.G1().Point()
+		require.Nil(t, pb.UnmarshalBinary(ma))
+		mb, err := pb.MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, ma, mb)
+	}
+}
+
+func TestG1UnmarshalInvalid(t *testing.T) {
+	suite := NewSuite()
+	buf, err := suite.G1().Point().Pick(random.New()).MarshalBinary()
+	require.Nil(t, err)
+
+	// Missing compression flag.
+	b := append([]byte{}, buf...)
+	b[0] &^= flagCompressed
+	require.NotNil(t, suite.G1().Point().UnmarshalBinary(b))
+
+	// Coordinate larger than the modulus.
+	b = make([]byte, len(buf))
+	for i := range b {
+		b[i] = 0xff
+	}
+	b[0] = flagCompressed | 0x1f
+	require.NotNil(t, suite.G1().Point().UnmarshalBinary(b))
+
+	// Infinity with a non-zero coordinate.
+	b = append([]byte{}, buf...)
+	b[0] |= flagInfinity
+	require.NotNil(t, suite.G1().Point().UnmarshalBinary(b))
+
+	// x = 4 is not the x-coordinate of a point in G₁.
+	b = make([]byte, len(buf))
+	b[0] = flagCompressed
+	b[len(b)-1] = 4
+	require.NotNil(t, suite.G1().Point().UnmarshalBinary(b))
+
+	require.NotNil(t, suite.G1().Point().UnmarshalBinary(buf[:10]))
+}
+
+func TestG1Ops(t *testing.T) {
+	suite := NewSuite()
+	a := suite.G1().Point().Pick(random.New())
+	b := suite.G1().Point().Pick(random.New())
+	c := a.Clone()
+	a.Neg(a)
+	a.Neg(a)
+	require.True(t, a.Equal(c))
+	a.Add(a, b)
+	a.Sub(a, b)
+	require.True(t, a.Equal(c))
+	a.Add(a, suite.G1().Point().Null())
+	require.True(t, a.Equal(c))
+}
+
+func TestG2Marshal(t *testing.T) {
+	suite := NewSuite()
+	for _, pa := range []interface {
+		MarshalBinary() ([]byte, error)
+	}{
+		suite.G2().Point().Pick(random.New()),
+		suite.G2().Point().Pick(random.New()),
+		suite.G2().Point().Null(),
+	} {
+		ma, err := pa.MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, suite.G2().PointLen(), len(ma))
+
+		pb := suite.G2().Point()
+		require.Nil(t, pb.UnmarshalBinary(ma))
+		mb, err := pb.MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, ma, mb)
+	}
+}
+
+func TestG2UnmarshalInvalid(t *testing.T) {
+	suite := NewSuite()
+	buf, err := suite.G2().Point().Pick(random.New()).MarshalBinary()
+	require.Nil(t, err)
+
+	b := append([]byte{}, buf...)
+	b[0] &^= flagCompressed
+	require.NotNil(t, suite.G2().Point().UnmarshalBinary(b))
+
+	b = append([]byte{}, buf...)
+	b[0] |= flagInfinity
+	require.NotNil(t, suite.G2().Point().UnmarshalBinary(b))
+
+	// Search for an x-coordinate on the twist that is not in G₂.
+	b = make([]byte, len(buf))
+	b[0] = flagCompressed
+	for i := byte(1); ; i++ {
+		b[len(b)-1] = i
+		x := &gfP2{y: *newGFp(int64(i))}
+		y := (&gfP2{}).Square(x)
+		y.Mul(y, x).Add(y, twistB)
+		if y.Sqrt(y) {
+			break
+		}
+	}
+	require.NotNil(t, suite.G2().Point().UnmarshalBinary(b))
+}
+
+func TestG2Ops(t *testing.T) {
+	suite := NewSuite()
+	a := suite.G2().Point().Pick(random.New())
+	b := suite.G2().Point().Pick(random.New())
+	c := a.Clone()
+	a.Neg(a)
+	a.Neg(a)
+	require.True(t, a.Equal(c))
+	a.Add(a, b)
+	a.Sub(a, b)
+	require.True(t, a.Equal(c))
+	a.Add(a, suite.G2().Point().Null())
+	require.True(t, a.Equal(c))
+}
+
+func TestGTMarshal(t *testing.T) {
+	suite := NewSuite()
+	k := suite.GT().Scalar().Pick(random.New())
+	pa := suite.GT().Point().Mul(k, nil)
+	ma, err := pa.MarshalBinary()
+	require.Nil(t, err)
+	pb := suite.GT().Point()
+	require.Nil(t, pb.UnmarshalBinary(ma))
+	mb, err := pb.MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, ma, mb)
+
+	// A random field element is not in GT.
+	ma[len(ma)-1] ^= 0x01
+	require.NotNil(t, pb.UnmarshalBinary(ma))
+}
+
+func TestGTOps(t *testing.T) {
+	suite := NewSuite()
+	a := suite.GT().Point().Pick(random.New())
+	b := suite.GT().Point().Pick(random.New())
+	c := a.Clone()
+	a.Neg(a)
+	a.Neg(a)
+	require.True(t, a.Equal(c))
+	a.Add(a, b)
+	a.Sub(a, b)
+	require.True(t, a.Equal(c))
+	a.Add(a, suite.GT().Point().Null())
+	require.True(t, a.Equal(c))
+	require.False(t, suite.GT().Point().Base().Equal(suite.GT().Point().Null()))
+}
+
+func TestBilinearity(t *testing.T) {
+	suite := NewSuite()
+	a := suite.G1().Scalar().Pick(random.New())
+	pa := suite.G1().Point().Mul(a, nil)
+	b := suite.G2().Scalar().Pick(random.New())
+	pb := suite.G2().Point().Mul(b, nil)
+	pc := suite.Pair(pa, pb)
+	pd := suite.Pair(suite.G1().Point().Base(), suite.G2().Point().Base())
+	pd = suite.GT().Point().Mul(a, pd)
+	pd = suite.GT().Point().Mul(b, pd)
+	require.True(t, pc.Equal(pd))
+
+	pe := suite.Pair(suite.G1().Point().Null(), pb)
+	require.True(t, pe.Equal(suite.GT().Point().Null()))
+}
+
+// TestPairingVector checks e(G₁, G₂) against the generator of GT of the
+// zkcrypto/bls12_381 crate, which is the pairing of the generators with a
+// final exponentiation to the power 3(p⁴-p²+1)/Order, hence the cube of the
+// pairing computed here. The coefficients are in the order of MarshalBinary.
+func TestPairingVector(t *testing.T) {
+	suite := NewSuite()
+	e := suite.Pair(suite.G1().Point().Base(), suite.G2().Point().Base())
+	e = suite.GT().Point().Mul(suite.GT().Scalar().SetInt64(3), e)
+	b, err := e.MarshalBinary()
+	require.Nil(t, err)
+	coefficients := []string{
+		"0f41e58663bf08cf068672cbd01a7ec73baca4d72ca93544deff686bfd6df543d48eaa24afe47e1efde449383b676631",
+		"04c581234d086a9902249b64728ffd21a189e87935a954051c7cdba7b3872629a4fafc05066245cb9108f0242d0fe3ef",
+		"03350f55a7aefcd3c31b4fcb6ce5771cc6a0e9786ab5973320c806ad360829107ba810c5a09ffdd9be2291a0c25a99a2",
+		"11b8b424cd48bf38fcef68083b0b0ec5c81a93b330ee1a677d0d15ff7b984e8978ef48881e32fac91b93b47333e2ba57",
+		"06fba23eb7c5af0d9f80940ca771b6ffd5857baaf222eb95a7d2809d61bfe02e1bfd1b68ff02f0b8102ae1c2d5d5ab1a",
+		"19f26337d205fb469cd6bd15c3d5a04dc88784fbb3d0b2dbdea54d43b2b73f2cbb12d58386a8703e0f948226e47ee89d",
+		"018107154f25a764bd3c79937a45b84546da634b8f6be14a8061e55cceba478b23f7dacaa35c8ca78beae9624045b4b6",
+		"01b2f522473d171391125ba84dc4007cfbf2f8da752f7c74185203fcca589ac719c34dffbbaad8431dad1c1fb597aaa5",
+		"193502b86edb8857c273fa075a50512937e0794e1e65a7617c90d8bd66065b1fffe51d7a579973b1315021ec3c19934f",
+		"1368bb445c7c2d209703f239689ce34c0378a68e72a6b3b216da0e22a5031b54ddff57309396b38c881c4c849ec23e87",
+		"089a1c5b46e5110b86750ec6a532348868a84045483c92b7af5af689452eafabf1a8943e50439f1d59882a98eaa0170f",
+		"1250ebd871fc0a92a7b2d83168d0d727272d441befa15c503dd8e90ce98db3e7b6d194f60839c508a84305aaca1789b6",
+	}
+	require.Equal(t, strings.Join(coefficients, ""), hex.EncodeToString(b))
+}
+
+func TestTripartiteDiffieHellman(t *testing.T) {
+	suite := NewSuite()
+	a := suite.G1().Scalar().Pick(random.New())
+	b := suite.G1().Scalar().Pick(random.New())
+	c := suite.G1().Scalar().Pick(random.New())
+	pa, pb, pc := suite.G1().Point().Mul(a, nil), suite.G1().Point().Mul(b, nil), suite.G1().Point().Mul(c, nil)
+	qa, qb, qc := suite.G2().Point().Mul(a, nil), suite.G2().Point().Mul(b, nil), suite.G2().Point().Mul(c, nil)
+	k1 := suite.Pair(pb, qc)
+	k1 = suite.GT().Point().Mul(a, k1)
+	k2 := suite.Pair(pc, qa)
+	k2 = suite.GT().Point().Mul(b, k2)
+	k3 := suite.Pair(pa, qb)
+	k3 = suite.GT().Point().Mul(c, k3)
+	require.True(t, k1.Equal(k2))
+	require.True(t, k2.Equal(k3))
+}
+
+func TestCombined(t *testing.T) {
+	basicPointTest(t, NewSuiteG1())
+	basicPointTest(t, NewSuiteG2())
+	basicPointTest(t, NewSuiteGT())
+}
+
+func basicPointTest(t *testing.T, s *Suite) {
+	a := s.Scalar().Pick(random.New())
+	pa := s.Point().Mul(a, nil)
+
+	b := s.Scalar().Add(a, s.Scalar().One())
+	pb1 := s.Point().Mul(b, nil)
+	pb2 := s.Point().Add(pa, s.Point().Base())
+	require.True(t, pb1.Equal(pb2))
+}
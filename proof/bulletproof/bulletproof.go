@@ -0,0 +1,433 @@
+// Package bulletproof implements the range proof of Bünz et al.,
+// "Bulletproofs: Short Proofs for Confidential Transactions and More",
+// https://eprint.iacr.org/2017/1066.pdf.
+//
+// A proof shows that a Pedersen commitment V = v·G + r·H, as computed by
+// package commit/pedersen, opens to a value v in [0, 2^n) without revealing v
+// nor r. It needs no trusted setup: all generators are derived by hashing.
+// The proof is made non-interactive with the Fiat-Shamir heuristic, and its
+// inner product argument only takes 2·log2(n) points.
+package bulletproof
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/commit/pedersen"
+)
+
+// Suite represents the set of functionalities needed by the package
+// bulletproof.
+type Suite interface {
+	kyber.Group
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// MaxBits is the largest supported range size n, in bits.
+const MaxBits = 64
+
+// Proof is a range proof for a single commitment.
+type Proof struct {
+	A, S   kyber.Point  // commitments to the bits of v and to the blinding vectors
+	T1, T2 kyber.Point  // commitments to the coefficients of t(X)
+	TauX   kyber.Scalar // blinding of t(x)
+	Mu     kyber.Scalar // blinding of A and S
+	T      kyber.Scalar // t(x) = <l(x), r(x)>
+	L, R   []kyber.Point
+	InnerA kyber.Scalar // final scalars of the inner product argument
+	InnerB kyber.Scalar
+}
+
+// generators holds the bases used by the proofs for a given n.
+type generators struct {
+	g, h kyber.Point
+	G, H []kyber.Point
+}
+
+func newGenerators(suite Suite, n int) *generators {
+	gens := &generators{
+		g: suite.Point().Base(),
+		h: pedersen.Setup(suite),
+		G: make([]kyber.Point, n),
+		H: make([]kyber.Point, n),
+	}
+	xofG := suite.XOF([]byte("bulletproof-G"))
+	xofH := suite.XOF([]byte("bulletproof-H"))
+	for i := 0; i < n; i++ {
+		gens.G[i] = suite.Point().Pick(xofG)
+		gens.H[i] = suite.Point().Pick(xofH)
+	}
+	return gens
+}
+
+// Prove creates a proof that the commitment v·G + r·H, with H given by
+// pedersen.Setup, opens to a value in [0, 2^n). n must be a power of two no
+// larger than MaxBits, and v must lie in the range.
+func Prove(suite Suite, v, r kyber.Scalar, n int) (*Proof, error) {
+	if err := checkBits(n); err != nil {
+		return nil, err
+	}
+	aL, err := bits(suite, v, n)
+	if err != nil {
+		return nil, err
+	}
+	gens := newGenerators(suite, n)
+	rand := suite.RandomStream()
+	one := suite.Scalar().One()
+
+	aR := make([]kyber.Scalar, n)
+	sL := make([]kyber.Scalar, n)
+	sR := make([]kyber.Scalar, n)
+	for i := range aR {
+		aR[i] = suite.Scalar().Sub(aL[i], one)
+		sL[i] = suite.Scalar().Pick(rand)
+		sR[i] = suite.Scalar().Pick(rand)
+	}
+
+	// A = α·h + <aL, G> + <aR, H> and S = ρ·h + <sL, G> + <sR, H>
+	alpha := suite.Scalar().Pick(rand)
+	rho := suite.Scalar().Pick(rand)
+	A := suite.Point().Mul(alpha, gens.h)
+	A.Add(A, multiExp(suite, gens.G, aL)).Add(A, multiExp(suite, gens.H, aR))
+	S := suite.Point().Mul(rho, gens.h)
+	S.Add(S, multiExp(suite, gens.G, sL)).Add(S, multiExp(suite, gens.H, sR))
+
+	V := suite.Point().Mul(v, nil)
+	V.Add(V, suite.Point().Mul(r, gens.h))
+	tr := newTranscript(suite, n)
+	tr.append(V, A, S)
+	y := tr.challenge()
+	z := tr.challenge()
+	z2 := suite.Scalar().Mul(z, z)
+
+	// l(X) = l0 + l1·X and r(X) = r0 + r1·X
+	yn := powers(suite, y, n)
+	twon := powers(suite, suite.Scalar().SetInt64(2), n)
+	l0 := make([]kyber.Scalar, n)
+	r0 := make([]kyber.Scalar, n)
+	r1 := make([]kyber.Scalar, n)
+	for i := 0; i < n; i++ {
+		l0[i] = suite.Scalar().Sub(aL[i], z)
+		r0[i] = suite.Scalar().Add(aR[i], z)
+		r0[i].Mul(r0[i], yn[i]).Add(r0[i], suite.Scalar().Mul(z2, twon[i]))
+		r1[i] = suite.Scalar().Mul(yn[i], sR[i])
+	}
+	t1 := innerProduct(suite, l0, r1)
+	t1.Add(t1, innerProduct(suite, sL, r0))
+	t2 := innerProduct(suite, sL, r1)
+
+	tau1 := suite.Scalar().Pick(rand)
+	tau2 := suite.Scalar().Pick(rand)
+	T1 := commit(suite, gens, t1, tau1)
+	T2 := commit(suite, gens, t2, tau2)
+	tr.append(T1, T2)
+	x := tr.challenge()
+
+	// τx = τ2·x² + τ1·x + z²·r and μ = α + ρ·x
+	taux := suite.Scalar().Mul(tau2, x)
+	taux.Add(taux, tau1).Mul(taux, x).Add(taux, suite.Scalar().Mul(z2, r))
+	mu := suite.Scalar().Mul(rho, x)
+	mu.Add(mu, alpha)
+
+	l := make([]kyber.Scalar, n)
+	rr := make([]kyber.Scalar, n)
+	for i := 0; i < n; i++ {
+		l[i] = suite.Scalar().Mul(sL[i], x)
+		l[i].Add(l[i], l0[i])
+		rr[i] = suite.Scalar().Mul(r1[i], x)
+		rr[i].Add(rr[i], r0[i])
+	}
+	t := innerProduct(suite, l, rr)
+
+	proof := &Proof{A: A, S: S, T1: T1, T2: T2, TauX: taux, Mu: mu, T: t}
+	tr.appendScalars(taux, mu, t)
+	u := suite.Point().Mul(tr.challenge(), gens.g)
+	proof.L, proof.R, proof.InnerA, proof.InnerB = proveInnerProduct(suite, tr, gens.G, hPrime(suite, gens.H, y), u, l, rr)
+	return proof, nil
+}
+
+// Verify checks that the commitment opens to a value in [0, 2^n).
+func Verify(suite Suite, commitment kyber.Point, n int, proof *Proof) error {
+	if err := checkBits(n); err != nil {
+		return err
+	}
+	if proof == nil || commitment == nil {
+		return errors.New("bulletproof: missing proof or commitment")
+	}
+	rounds := 0
+	for m := n; m > 1; m /= 2 {
+		rounds++
+	}
+	if len(proof.L) != rounds || len(proof.R) != rounds {
+		return errors.New("bulletproof: invalid proof size")
+	}
+	if !proof.complete() {
+		return errors.New("bulletproof: incomplete proof")
+	}
+	gens := newGenerators(suite, n)
+
+	tr := newTranscript(suite, n)
+	tr.append(commitment, proof.A, proof.S)
+	y := tr.challenge()
+	z := tr.challenge()
+	tr.append(proof.T1, proof.T2)
+	x := tr.challenge()
+	tr.appendScalars(proof.TauX, proof.Mu, proof.T)
+	w := tr.challenge()
+
+	z2 := suite.Scalar().Mul(z, z)
+	z3 := suite.Scalar().Mul(z2, z)
+	x2 := suite.Scalar().Mul(x, x)
+	yn := powers(suite, y, n)
+	twon := powers(suite, suite.Scalar().SetInt64(2), n)
+
+	// t·g + τx·h = z²·V + δ(y,z)·g + x·T1 + x²·T2 with
+	// δ(y,z) = (z - z²)·<1, y^n> - z³·<1, 2^n>
+	delta := suite.Scalar().Sub(z, z2)
+	delta.Mul(delta, sum(suite, yn))
+	delta.Sub(delta, suite.Scalar().Mul(z3, sum(suite, twon)))
+	left := commit(suite, gens, proof.T, proof.TauX)
+	right := suite.Point().Mul(z2, commitment)
+	right.Add(right, suite.Point().Mul(delta, gens.g))
+	right.Add(right, suite.Point().Mul(x, proof.T1))
+	right.Add(right, suite.Point().Mul(x2, proof.T2))
+	if !left.Equal(right) {
+		return errors.New("bulletproof: invalid polynomial commitment")
+	}
+
+	// P = A + x·S - <z·1, G> + <z·y^n + z²·2^n, H'> - μ·h + t·u
+	Hp := hPrime(suite, gens.H, y)
+	minusZ := suite.Scalar().Neg(z)
+	gs := make([]kyber.Scalar, n)
+	hs := make([]kyber.Scalar, n)
+	for i := 0; i < n; i++ {
+		gs[i] = minusZ
+		hs[i] = suite.Scalar().Mul(z, yn[i])
+		hs[i].Add(hs[i], suite.Scalar().Mul(z2, twon[i]))
+	}
+	u := suite.Point().Mul(w, gens.g)
+	P := suite.Point().Mul(x, proof.S)
+	P.Add(P, proof.A)
+	P.Add(P, multiExp(suite, gens.G, gs)).Add(P, multiExp(suite, Hp, hs))
+	P.Sub(P, suite.Point().Mul(proof.Mu, gens.h))
+	P.Add(P, suite.Point().Mul(proof.T, u))
+
+	if !verifyInnerProduct(suite, tr, gens.G, Hp, u, P, proof) {
+		return errors.New("bulletproof: invalid inner product argument")
+	}
+	return nil
+}
+
+// complete tells whether none of the points and scalars of the proof is nil.
+func (p *Proof) complete() bool {
+	for _, P := range append([]kyber.Point{p.A, p.S, p.T1, p.T2}, append(p.L, p.R...)...) {
+		if P == nil {
+			return false
+		}
+	}
+	for _, s := range []kyber.Scalar{p.TauX, p.Mu, p.T, p.InnerA, p.InnerB} {
+		if s == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// proveInnerProduct proves knowledge of a and b such that
+// P = <a, G> + <b, H> + <a, b>·u, halving the vectors at each round.
+func proveInnerProduct(suite Suite, tr *transcript, G, H []kyber.Point, u kyber.Point, a, b []kyber.Scalar) ([]kyber.Point, []kyber.Point, kyber.Scalar, kyber.Scalar) {
+	var Ls, Rs []kyber.Point
+	for len(a) > 1 {
+		m := len(a) / 2
+		cL := innerProduct(suite, a[:m], b[m:])
+		cR := innerProduct(suite, a[m:], b[:m])
+		L := multiExp(suite, G[m:], a[:m])
+		L.Add(L, multiExp(suite, H[:m], b[m:])).Add(L, suite.Point().Mul(cL, u))
+		R := multiExp(suite, G[:m], a[m:])
+		R.Add(R, multiExp(suite, H[m:], b[:m])).Add(R, suite.Point().Mul(cR, u))
+		Ls = append(Ls, L)
+		Rs = append(Rs, R)
+
+		tr.append(L, R)
+		x := tr.challenge()
+		xinv := suite.Scalar().Inv(x)
+		G, H = foldPoints(suite, G, xinv, x), foldPoints(suite, H, x, xinv)
+		a, b = foldScalars(suite, a, x, xinv), foldScalars(suite, b, xinv, x)
+	}
+	return Ls, Rs, a[0], b[0]
+}
+
+// verifyInnerProduct checks the inner product argument of the proof for P.
+func verifyInnerProduct(suite Suite, tr *transcript, G, H []kyber.Point, u, P kyber.Point, proof *Proof) bool {
+	for i := range proof.L {
+		tr.append(proof.L[i], proof.R[i])
+		x := tr.challenge()
+		xinv := suite.Scalar().Inv(x)
+		x2 := suite.Scalar().Mul(x, x)
+		xinv2 := suite.Scalar().Mul(xinv, xinv)
+
+		// P' = x²·L + P + x⁻²·R
+		P.Add(P, suite.Point().Mul(x2, proof.L[i]))
+		P.Add(P, suite.Point().Mul(xinv2, proof.R[i]))
+		G, H = foldPoints(suite, G, xinv, x), foldPoints(suite, H, x, xinv)
+	}
+	ab := suite.Scalar().Mul(proof.InnerA, proof.InnerB)
+	Q := suite.Point().Mul(proof.InnerA, G[0])
+	Q.Add(Q, suite.Point().Mul(proof.InnerB, H[0]))
+	Q.Add(Q, suite.Point().Mul(ab, u))
+	return Q.Equal(P)
+}
+
+// commit returns v·g + r·h.
+func commit(suite Suite, gens *generators, v, r kyber.Scalar) kyber.Point {
+	c := suite.Point().Mul(v, gens.g)
+	return c.Add(c, suite.Point().Mul(r, gens.h))
+}
+
+// hPrime returns the bases H'[i] = y^-i·H[i].
+func hPrime(suite Suite, H []kyber.Point, y kyber.Scalar) []kyber.Point {
+	yinv := powers(suite, suite.Scalar().Inv(y), len(H))
+	Hp := make([]kyber.Point, len(H))
+	for i := range H {
+		Hp[i] = suite.Point().Mul(yinv[i], H[i])
+	}
+	return Hp
+}
+
+// foldPoints returns lo·P[:m] + hi·P[m:].
+func foldPoints(suite Suite, P []kyber.Point, lo, hi kyber.Scalar) []kyber.Point {
+	m := len(P) / 2
+	res := make([]kyber.Point, m)
+	for i := range res {
+		res[i] = suite.Point().Mul(lo, P[i])
+		res[i].Add(res[i], suite.Point().Mul(hi, P[m+i]))
+	}
+	return res
+}
+
+// foldScalars returns lo·s[:m] + hi·s[m:].
+func foldScalars(suite Suite, s []kyber.Scalar, lo, hi kyber.Scalar) []kyber.Scalar {
+	m := len(s) / 2
+	res := make([]kyber.Scalar, m)
+	for i := range res {
+		res[i] = suite.Scalar().Mul(lo, s[i])
+		res[i].Add(res[i], suite.Scalar().Mul(hi, s[m+i]))
+	}
+	return res
+}
+
+func multiExp(suite Suite, P []kyber.Point, s []kyber.Scalar) kyber.Point {
+	res := suite.Point().Null()
+	tmp := suite.Point()
+	for i := range P {
+		res.Add(res, tmp.Mul(s[i], P[i]))
+	}
+	return res
+}
+
+func innerProduct(suite Suite, a, b []kyber.Scalar) kyber.Scalar {
+	res := suite.Scalar().Zero()
+	tmp := suite.Scalar()
+	for i := range a {
+		res.Add(res, tmp.Mul(a[i], b[i]))
+	}
+	return res
+}
+
+// powers returns 1, x, x², ..., x^(n-1).
+func powers(suite Suite, x kyber.Scalar, n int) []kyber.Scalar {
+	res := make([]kyber.Scalar, n)
+	res[0] = suite.Scalar().One()
+	for i := 1; i < n; i++ {
+		res[i] = suite.Scalar().Mul(res[i-1], x)
+	}
+	return res
+}
+
+func sum(suite Suite, s []kyber.Scalar) kyber.Scalar {
+	res := suite.Scalar().Zero()
+	for _, si := range s {
+		res.Add(res, si)
+	}
+	return res
+}
+
+func checkBits(n int) error {
+	if n < 1 || n > MaxBits || n&(n-1) != 0 {
+		return fmt.Errorf("bulletproof: range size %d is not a power of two up to %d", n, MaxBits)
+	}
+	return nil
+}
+
+// bits returns the n bits of v as scalars, or an error if v >= 2^n.
+func bits(suite Suite, v kyber.Scalar, n int) ([]kyber.Scalar, error) {
+	b, err := v.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// Groups encode their scalars either in little or in big endian.
+	one, err := suite.Scalar().One().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if one[0] != 1 {
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+	}
+	for i := n / 8; i < len(b); i++ {
+		if n%8 != 0 && i == n/8 {
+			if b[i]>>uint(n%8) != 0 {
+				return nil, errors.New("bulletproof: value out of range")
+			}
+			continue
+		}
+		if b[i] != 0 {
+			return nil, errors.New("bulletproof: value out of range")
+		}
+	}
+	res := make([]kyber.Scalar, n)
+	for i := range res {
+		res[i] = suite.Scalar().SetInt64(int64(b[i/8]>>uint(i%8)) & 1)
+	}
+	return res, nil
+}
+
+// transcript derives the Fiat-Shamir challenges from the messages of the
+// prover.
+type transcript struct {
+	suite Suite
+	buf   []byte
+}
+
+func newTranscript(suite Suite, n int) *transcript {
+	return &transcript{suite: suite, buf: []byte(fmt.Sprintf("bulletproof-%d", n))}
+}
+
+func (t *transcript) append(points ...kyber.Point) {
+	for _, p := range points {
+		b, err := p.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		t.buf = append(t.buf, b...)
+	}
+}
+
+func (t *transcript) appendScalars(scalars ...kyber.Scalar) {
+	for _, s := range scalars {
+		b, err := s.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		t.buf = append(t.buf, b...)
+	}
+}
+
+func (t *transcript) challenge() kyber.Scalar {
+	c := t.suite.Scalar().Pick(t.suite.XOF(t.buf))
+	t.appendScalars(c)
+	return c
+}
@@ -0,0 +1,157 @@
+package bulletproof
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/commit/pedersen"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func commitment(v, r kyber.Scalar) kyber.Point {
+	V := suite.Point().Mul(v, nil)
+	return V.Add(V, suite.Point().Mul(r, pedersen.Setup(suite)))
+}
+
+func TestBulletproof(t *testing.T) {
+	for n, values := range map[int][]int64{
+		1:  {0, 1},
+		8:  {0, 1, 42, 255},
+		32: {0, 1 << 31, 1<<32 - 1},
+		64: {0, 1 << 62, 1<<63 - 1},
+	} {
+		for _, value := range values {
+			v := suite.Scalar().SetInt64(value)
+			r := suite.Scalar().Pick(suite.RandomStream())
+			proof, err := Prove(suite, v, r, n)
+			require.Nil(t, err)
+			require.Nil(t, Verify(suite, commitment(v, r), n, proof), "n=%d v=%d", n, value)
+
+			// another value or blinding factor fail
+			require.Error(t, Verify(suite, commitment(suite.Scalar().SetInt64(value+1), r), n, proof))
+			require.Error(t, Verify(suite, commitment(v, suite.Scalar().One()), n, proof))
+		}
+	}
+}
+
+func TestBulletproofOutOfRange(t *testing.T) {
+	r := suite.Scalar().Pick(suite.RandomStream())
+	_, err := Prove(suite, suite.Scalar().SetInt64(256), r, 8)
+	require.Error(t, err)
+	_, err = Prove(suite, suite.Scalar().SetInt64(1<<32), r, 32)
+	require.Error(t, err)
+	_, err = Prove(suite, suite.Scalar().SetInt64(-1), r, 64)
+	require.Error(t, err)
+
+	for _, n := range []int{0, 3, 128} {
+		_, err = Prove(suite, suite.Scalar().Zero(), r, n)
+		require.Error(t, err)
+	}
+}
+
+func TestBulletproofTampered(t *testing.T) {
+	n := 32
+	v := suite.Scalar().SetInt64(123456)
+	r := suite.Scalar().Pick(suite.RandomStream())
+	V := commitment(v, r)
+	proof, err := Prove(suite, v, r, n)
+	require.Nil(t, err)
+
+	// a proof for n bits is not valid for fewer bits
+	require.Error(t, Verify(suite, V, 16, proof))
+
+	one := suite.Scalar().One()
+	g := suite.Point().Base()
+	tampered := []func(p *Proof){
+		func(p *Proof) { p.A = suite.Point().Add(p.A, g) },
+		func(p *Proof) { p.T1 = suite.Point().Add(p.T1, g) },
+		func(p *Proof) { p.TauX = suite.Scalar().Add(p.TauX, one) },
+		func(p *Proof) { p.Mu = suite.Scalar().Add(p.Mu, one) },
+		func(p *Proof) { p.T = suite.Scalar().Add(p.T, one) },
+		func(p *Proof) { p.L[1] = suite.Point().Add(p.L[1], g) },
+		func(p *Proof) { p.InnerB = suite.Scalar().Add(p.InnerB, one) },
+	}
+	for i, tamper := range tampered {
+		p := *proof
+		p.L = append([]kyber.Point{}, proof.L...)
+		tamper(&p)
+		require.Error(t, Verify(suite, V, n, &p), "tampering %d", i)
+	}
+}
+
+func TestBulletproofIncomplete(t *testing.T) {
+	n := 8
+	v := suite.Scalar().SetInt64(42)
+	r := suite.Scalar().Pick(suite.RandomStream())
+	V := commitment(v, r)
+	proof, err := Prove(suite, v, r, n)
+	require.Nil(t, err)
+
+	require.Error(t, Verify(suite, V, n, nil))
+	require.Error(t, Verify(suite, nil, n, proof))
+	incomplete := []func(p *Proof){
+		func(p *Proof) { p.A = nil },
+		func(p *Proof) { p.S = nil },
+		func(p *Proof) { p.T1 = nil },
+		func(p *Proof) { p.T2 = nil },
+		func(p *Proof) { p.TauX = nil },
+		func(p *Proof) { p.Mu = nil },
+		func(p *Proof) { p.T = nil },
+		func(p *Proof) { p.L[0] = nil },
+		func(p *Proof) { p.R[2] = nil },
+		func(p *Proof) { p.InnerA = nil },
+		func(p *Proof) { p.InnerB = nil },
+	}
+	for i, remove := range incomplete {
+		p := *proof
+		p.L = append([]kyber.Point{}, proof.L...)
+		p.R = append([]kyber.Point{}, proof.R...)
+		remove(&p)
+		require.Error(t, Verify(suite, V, n, &p), "removal %d", i)
+	}
+}
+
+func TestBulletproofSize(t *testing.T) {
+	r := suite.Scalar().Pick(suite.RandomStream())
+	for n, rounds := range map[int]int{8: 3, 32: 5, 64: 6} {
+		proof, err := Prove(suite, suite.Scalar().SetInt64(7), r, n)
+		require.Nil(t, err)
+		// 4 + 2·log2(n) points and 5 scalars
+		require.Len(t, proof.L, rounds)
+		require.Len(t, proof.R, rounds)
+	}
+}
+
+func benchmarkProve(b *testing.B, n int) {
+	v := suite.Scalar().SetInt64(1234)
+	r := suite.Scalar().Pick(suite.RandomStream())
+	for i := 0; i < b.N; i++ {
+		if _, err := Prove(suite, v, r, n); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkVerify(b *testing.B, n int) {
+	v := suite.Scalar().SetInt64(1234)
+	r := suite.Scalar().Pick(suite.RandomStream())
+	V := commitment(v, r)
+	proof, err := Prove(suite, v, r, n)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Verify(suite, V, n, proof); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProve32(b *testing.B)  { benchmarkProve(b, 32) }
+func BenchmarkProve64(b *testing.B)  { benchmarkProve(b, 64) }
+func BenchmarkVerify32(b *testing.B) { benchmarkVerify(b, 32) }
+func BenchmarkVerify64(b *testing.B) { benchmarkVerify(b, 64) }
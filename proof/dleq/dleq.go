@@ -61,6 +61,21 @@ func NewDLEQProof(suite Suite, G kyber.Point, H kyber.Point, x kyber.Scalar) (pr
 	return &Proof{c, r, vG, vH}, xG, xH, nil
 }
 
+// ProveDLEQ computes a NIZK dlog-equality proof, as NewDLEQProof, that the
+// public points pub1 = priv*base1 and pub2 = priv*base2 have the same discrete
+// logarithm, as in the Chaum-Pedersen protocol. It returns an error if priv is
+// not the discrete logarithm of the public points.
+func ProveDLEQ(suite Suite, base1, base2, pub1, pub2 kyber.Point, priv kyber.Scalar) (*Proof, error) {
+	proof, xG, xH, err := NewDLEQProof(suite, base1, base2, priv)
+	if err != nil {
+		return nil, err
+	}
+	if !xG.Equal(pub1) || !xH.Equal(pub2) {
+		return nil, errors.New("dleq: public points do not match the private key")
+	}
+	return proof, nil
+}
+
 // NewDLEQProofBatch computes lists of NIZK dlog-equality proofs and of
 // encrypted base points xG and xH. Note that the challenge is computed over all
 // input values.
@@ -1,6 +1,7 @@
 package dleq
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/dedis/kyber"
@@ -59,3 +60,77 @@ func TestDLEQLengths(t *testing.T) {
 	_, _, _, err := NewDLEQProofBatch(suite, g, h, x)
 	require.Equal(t, err, errorDifferentLengths)
 }
+
+func TestDLProof(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	msg := []byte("proof of knowledge")
+	x := suite.Scalar().Pick(rng)
+	g := suite.Point().Pick(rng)
+	xG := suite.Point().Mul(x, g)
+	proof, err := Prove(suite, g, xG, x, msg)
+	require.Nil(t, err)
+	require.Nil(t, Verify(suite, g, xG, msg, proof))
+
+	require.Equal(t, errorInvalidProof, Verify(suite, g, xG, []byte("another message"), proof))
+	require.Equal(t, errorInvalidProof, Verify(suite, suite.Point().Pick(rng), xG, msg, proof))
+	require.Equal(t, errorInvalidProof, Verify(suite, g, suite.Point().Pick(rng), msg, proof))
+	forged := &DLProof{C: proof.C, R: suite.Scalar().Add(proof.R, suite.Scalar().One())}
+	require.Equal(t, errorInvalidProof, Verify(suite, g, xG, msg, forged))
+	require.Equal(t, errorInvalidProof, Verify(suite, g, xG, msg, nil))
+
+	// a proof for another secret does not verify
+	y := suite.Scalar().Pick(rng)
+	proof, err = Prove(suite, g, xG, y, msg)
+	require.Nil(t, err)
+	require.Equal(t, errorInvalidProof, Verify(suite, g, xG, msg, proof))
+}
+
+func TestProveDLEQ(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	x := suite.Scalar().Pick(rng)
+	g := suite.Point().Pick(rng)
+	h := suite.Point().Pick(rng)
+	xG := suite.Point().Mul(x, g)
+	xH := suite.Point().Mul(x, h)
+	proof, err := ProveDLEQ(suite, g, h, xG, xH, x)
+	require.Nil(t, err)
+	require.Nil(t, proof.Verify(suite, g, h, xG, xH))
+
+	_, err = ProveDLEQ(suite, g, h, xG, suite.Point().Mul(x, g), x)
+	require.Error(t, err)
+}
+
+// TestVectors checks proofs computed with a random stream derived from a
+// fixed seed, so that a change of the proof encoding or of the challenges is
+// noticed.
+func TestVectors(t *testing.T) {
+	seed := edwards25519.NewBlakeSHA256Ed25519()
+	suite := edwards25519.NewBlakeSHA256Ed25519WithRand(seed.XOF([]byte("dleq test vectors")))
+	x := suite.Scalar().Pick(suite.RandomStream())
+	g := suite.Point().Pick(suite.RandomStream())
+	h := suite.Point().Pick(suite.RandomStream())
+	xG := suite.Point().Mul(x, g)
+	xH := suite.Point().Mul(x, h)
+	encode := func(m interface{ MarshalBinary() ([]byte, error) }) string {
+		b, err := m.MarshalBinary()
+		require.Nil(t, err)
+		return hex.EncodeToString(b)
+	}
+
+	dl, err := Prove(suite, g, xG, x, []byte("message"))
+	require.Nil(t, err)
+	require.Nil(t, Verify(suite, g, xG, []byte("message"), dl))
+	require.Equal(t, "62f47c93c959be3da6e346a13f165b68f7ca0c089d89b666cca4a9f87271ca02", encode(x))
+	require.Equal(t, "0b53d322fe024820fa84c08bd211b582f206754eb4b4bb9c99738600e68fe974", encode(g))
+	require.Equal(t, "80d5d2872748aabc3695412aa1cc506d9c7e154e547d04e5bd5d13faafd4d77e", encode(h))
+	require.Equal(t, "f6d9e32cac331931a9aaa169388a9d8021254336e9996bee8d57cfe12a74a700", encode(dl.C))
+	require.Equal(t, "26835c5caded4878e82745758310d69c7f380c0fe97e7350b0c148c7832b2305", encode(dl.R))
+
+	eq, err := ProveDLEQ(suite, g, h, xG, xH, x)
+	require.Nil(t, err)
+	require.Nil(t, eq.Verify(suite, g, h, xG, xH))
+	require.Equal(t, "071955b0f2141922a70b0e6b4012aaf128b415d67b727369d4893eb05e60000c", encode(eq.C))
+	require.Equal(t, "6c63ced99e618be75266488d79e54ed465a11706c712d85473436a8c287ad509", encode(eq.R))
+	require.Equal(t, "4e706f46f12585bd449550304259d3ab3caa2428b583f2047ac130abe051c9f1", encode(eq.VG))
+	require.Equal(t, "6bdbd3bc0e5030b31404399df5f038d107aaeb2671b6a527956c8e764a0a3fe4", encode(eq.VH))
+}
@@ -0,0 +1,61 @@
+package dleq
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// DLProof represents a NIZK proof of knowledge of the discrete logarithm x of
+// xG with respect to the base point G, that is a Schnorr identification made
+// non-interactive with the Fiat-Shamir heuristic and bound to a message.
+type DLProof struct {
+	C kyber.Scalar // challenge
+	R kyber.Scalar // response
+}
+
+// Prove computes a NIZK proof of knowledge of the scalar x such that pub =
+// x*base. It randomly selects a commitment v and then computes the challenge
+// c = H(base,pub,vG,msg) and the response r = v - cx. The proof can only be
+// verified for the same message msg. For the proof that two points have the
+// same discrete logarithm, see NewDLEQProof.
+func Prove(suite Suite, base, pub kyber.Point, x kyber.Scalar, msg []byte) (*DLProof, error) {
+	v := suite.Scalar().Pick(suite.RandomStream())
+	vG := suite.Point().Mul(v, base)
+	c, err := dlChallenge(suite, base, pub, vG, msg)
+	if err != nil {
+		return nil, err
+	}
+	r := suite.Scalar()
+	r.Mul(x, c).Sub(v, r)
+	return &DLProof{c, r}, nil
+}
+
+// Verify examines the validity of the NIZK proof of knowledge of the discrete
+// logarithm of pub with respect to base, for the message msg. The commitment
+// is recomputed as vG = rG + c(pub), and the proof is valid if the challenge
+// of vG is c.
+func Verify(suite Suite, base, pub kyber.Point, msg []byte, proof *DLProof) error {
+	if proof == nil || proof.C == nil || proof.R == nil {
+		return errorInvalidProof
+	}
+	vG := suite.Point().Mul(proof.R, base)
+	vG.Add(vG, suite.Point().Mul(proof.C, pub))
+	c, err := dlChallenge(suite, base, pub, vG, msg)
+	if err != nil {
+		return err
+	}
+	if !c.Equal(proof.C) {
+		return errorInvalidProof
+	}
+	return nil
+}
+
+func dlChallenge(suite Suite, base, pub, vG kyber.Point, msg []byte) (kyber.Scalar, error) {
+	h := suite.Hash()
+	for _, p := range []kyber.Point{base, pub, vG} {
+		if _, err := p.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	h.Write(msg)
+	return suite.Scalar().Pick(suite.XOF(h.Sum(nil))), nil
+}
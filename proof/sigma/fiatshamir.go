@@ -0,0 +1,66 @@
+package sigma
+
+import (
+	"bytes"
+
+	"github.com/dedis/kyber"
+)
+
+// Proof is a non-interactive proof produced by the Fiat-Shamir transform of a
+// Protocol.
+type Proof struct {
+	Commit   []kyber.Point
+	Response []kyber.Scalar
+}
+
+// Prove runs the protocol p with the challenge derived from a hash of the
+// context, of the public points of the statement and of the commitment. The
+// context separates proofs of different applications.
+func Prove(suite Suite, p Protocol, context []byte) (*Proof, error) {
+	commit, err := p.Commit()
+	if err != nil {
+		return nil, err
+	}
+	c, err := challenge(suite, p, context, commit)
+	if err != nil {
+		return nil, err
+	}
+	p.Challenge(c)
+	response, err := p.Respond()
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{commit, response}, nil
+}
+
+// Verify checks a proof produced by Prove for the statement of p with the
+// same context. A proof with missing commitments or responses is invalid.
+func Verify(suite Suite, p Protocol, context []byte, proof *Proof) error {
+	if proof == nil {
+		return errInvalidProof
+	}
+	for _, s := range proof.Response {
+		if s == nil {
+			return errInvalidProof
+		}
+	}
+	c, err := challenge(suite, p, context, proof.Commit)
+	if err != nil {
+		return err
+	}
+	return p.Verify(proof.Commit, c, proof.Response)
+}
+
+func challenge(suite Suite, p Protocol, context []byte, commit []kyber.Point) (kyber.Scalar, error) {
+	var buf bytes.Buffer
+	buf.Write(context)
+	for _, P := range append(p.Public(), commit...) {
+		if P == nil {
+			return nil, errInvalidProof
+		}
+		if _, err := P.MarshalTo(&buf); err != nil {
+			return nil, err
+		}
+	}
+	return suite.Scalar().Pick(suite.XOF(buf.Bytes())), nil
+}
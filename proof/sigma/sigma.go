@@ -0,0 +1,349 @@
+// Package sigma provides composable Sigma-protocols: a Schnorr proof of
+// knowledge of a discrete logarithm, the AND and OR combinators, and the
+// Fiat-Shamir transform to make any composition non-interactive.
+//
+// Unlike the predicate framework of package proof, protocols are plain values
+// nested in any order, so an AND can combine OR protocols and the other way
+// round. The OR combinator uses the technique of Cramer, Damgård and
+// Schoenmakers: the prover simulates the branches it has no witness for and
+// splits the challenge so that only one branch is proved for real.
+//
+// A Protocol drawn with a witness is a prover for a single run; the same
+// values without witnesses are verifiers and can be reused.
+package sigma
+
+import (
+	"errors"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed by the package sigma.
+type Suite interface {
+	kyber.Group
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// ErrNoWitness is returned by Commit when the prover does not know a witness
+// for the statement.
+var ErrNoWitness = errors.New("sigma: no witness")
+
+var errInvalidProof = errors.New("sigma: invalid proof")
+
+// Protocol is a Sigma-protocol for some statement. Commitments and responses
+// are lists so that protocols can be composed.
+type Protocol interface {
+	// Commit returns the first message of the prover, or ErrNoWitness.
+	Commit() ([]kyber.Point, error)
+	// Challenge gives the challenge of the verifier to the prover.
+	Challenge(c kyber.Scalar)
+	// Respond returns the response of the prover to the challenge.
+	Respond() ([]kyber.Scalar, error)
+
+	// Verify checks the transcript of a run of the protocol.
+	Verify(commit []kyber.Point, c kyber.Scalar, response []kyber.Scalar) error
+	// Simulate returns an accepting transcript for the challenge c with no
+	// need for a witness.
+	Simulate(c kyber.Scalar) ([]kyber.Point, []kyber.Scalar, error)
+	// Len returns the number of points of a commitment and of scalars of a
+	// response.
+	Len() (commits, responses int)
+	// Public returns the public points of the statement.
+	Public() []kyber.Point
+}
+
+type dlog struct {
+	suite Suite
+	G, X  kyber.Point
+	x     kyber.Scalar // witness, or nil
+	k     kyber.Scalar // nonce of the current run
+	c     kyber.Scalar
+}
+
+// DLog returns the Schnorr protocol proving the knowledge of x such that
+// X = x·G. The witness x is nil for a verifier. A nil G stands for the
+// standard base point.
+func DLog(suite Suite, G, X kyber.Point, x kyber.Scalar) Protocol {
+	if G == nil {
+		G = suite.Point().Base()
+	}
+	return &dlog{suite: suite, G: G, X: X, x: x}
+}
+
+func (d *dlog) Commit() ([]kyber.Point, error) {
+	if d.x == nil {
+		return nil, ErrNoWitness
+	}
+	d.k = d.suite.Scalar().Pick(d.suite.RandomStream())
+	return []kyber.Point{d.suite.Point().Mul(d.k, d.G)}, nil
+}
+
+func (d *dlog) Challenge(c kyber.Scalar) {
+	d.c = c
+}
+
+func (d *dlog) Respond() ([]kyber.Scalar, error) {
+	if d.k == nil || d.c == nil {
+		return nil, errors.New("sigma: response before commitment and challenge")
+	}
+	// s = k + c·x
+	s := d.suite.Scalar().Mul(d.c, d.x)
+	s.Add(s, d.k)
+	d.k = nil
+	return []kyber.Scalar{s}, nil
+}
+
+func (d *dlog) Verify(commit []kyber.Point, c kyber.Scalar, response []kyber.Scalar) error {
+	if len(commit) != 1 || len(response) != 1 {
+		return errInvalidProof
+	}
+	// s·G == T + c·X
+	left := d.suite.Point().Mul(response[0], d.G)
+	right := d.suite.Point().Mul(c, d.X)
+	right.Add(right, commit[0])
+	if !left.Equal(right) {
+		return errInvalidProof
+	}
+	return nil
+}
+
+func (d *dlog) Simulate(c kyber.Scalar) ([]kyber.Point, []kyber.Scalar, error) {
+	// T = s·G - c·X for a random s
+	s := d.suite.Scalar().Pick(d.suite.RandomStream())
+	T := d.suite.Point().Mul(s, d.G)
+	T.Sub(T, d.suite.Point().Mul(c, d.X))
+	return []kyber.Point{T}, []kyber.Scalar{s}, nil
+}
+
+func (d *dlog) Len() (int, int) { return 1, 1 }
+
+func (d *dlog) Public() []kyber.Point { return []kyber.Point{d.G, d.X} }
+
+type and struct {
+	ps []Protocol
+}
+
+// AND returns the protocol proving all the statements of ps. The same
+// challenge is used for each of them.
+func AND(ps ...Protocol) Protocol {
+	return &and{ps}
+}
+
+func (a *and) Commit() ([]kyber.Point, error) {
+	var commit []kyber.Point
+	for _, p := range a.ps {
+		t, err := p.Commit()
+		if err != nil {
+			return nil, err
+		}
+		commit = append(commit, t...)
+	}
+	return commit, nil
+}
+
+func (a *and) Challenge(c kyber.Scalar) {
+	for _, p := range a.ps {
+		p.Challenge(c)
+	}
+}
+
+func (a *and) Respond() ([]kyber.Scalar, error) {
+	var response []kyber.Scalar
+	for _, p := range a.ps {
+		s, err := p.Respond()
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, s...)
+	}
+	return response, nil
+}
+
+func (a *and) Verify(commit []kyber.Point, c kyber.Scalar, response []kyber.Scalar) error {
+	nc, nr := a.Len()
+	if len(commit) != nc || len(response) != nr {
+		return errInvalidProof
+	}
+	for _, p := range a.ps {
+		nc, nr := p.Len()
+		if err := p.Verify(commit[:nc], c, response[:nr]); err != nil {
+			return err
+		}
+		commit, response = commit[nc:], response[nr:]
+	}
+	return nil
+}
+
+func (a *and) Simulate(c kyber.Scalar) ([]kyber.Point, []kyber.Scalar, error) {
+	var commit []kyber.Point
+	var response []kyber.Scalar
+	for _, p := range a.ps {
+		t, s, err := p.Simulate(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		commit, response = append(commit, t...), append(response, s...)
+	}
+	return commit, response, nil
+}
+
+func (a *and) Len() (int, int) {
+	var nc, nr int
+	for _, p := range a.ps {
+		c, r := p.Len()
+		nc, nr = nc+c, nr+r
+	}
+	return nc, nr
+}
+
+func (a *and) Public() []kyber.Point {
+	var public []kyber.Point
+	for _, p := range a.ps {
+		public = append(public, p.Public()...)
+	}
+	return public
+}
+
+type or struct {
+	suite Suite
+	ps    []Protocol
+	// state of the current run
+	real      int
+	commits   [][]kyber.Point
+	responses [][]kyber.Scalar
+	cs        []kyber.Scalar
+}
+
+// OR returns the protocol proving one of the statements of ps, without
+// revealing which one. The prover needs a witness for one of them only.
+//
+// The response starts with the challenges of all the branches, which sum up
+// to the challenge of the verifier.
+func OR(suite Suite, ps ...Protocol) Protocol {
+	return &or{suite: suite, ps: ps}
+}
+
+func (o *or) Commit() ([]kyber.Point, error) {
+	o.real = -1
+	o.commits = make([][]kyber.Point, len(o.ps))
+	o.responses = make([][]kyber.Scalar, len(o.ps))
+	o.cs = make([]kyber.Scalar, len(o.ps))
+	for i, p := range o.ps {
+		if o.real < 0 {
+			t, err := p.Commit()
+			if err == nil {
+				o.real, o.commits[i] = i, t
+				continue
+			}
+			if err != ErrNoWitness {
+				return nil, err
+			}
+		}
+		o.cs[i] = o.suite.Scalar().Pick(o.suite.RandomStream())
+		t, s, err := p.Simulate(o.cs[i])
+		if err != nil {
+			return nil, err
+		}
+		o.commits[i], o.responses[i] = t, s
+	}
+	if o.real < 0 {
+		return nil, ErrNoWitness
+	}
+	var commit []kyber.Point
+	for _, t := range o.commits {
+		commit = append(commit, t...)
+	}
+	return commit, nil
+}
+
+func (o *or) Challenge(c kyber.Scalar) {
+	if o.real < 0 || o.cs == nil {
+		return
+	}
+	// the real branch gets c minus the challenges of the simulated ones
+	cr := o.suite.Scalar().Set(c)
+	for i, ci := range o.cs {
+		if i != o.real {
+			cr.Sub(cr, ci)
+		}
+	}
+	o.cs[o.real] = cr
+	o.ps[o.real].Challenge(cr)
+}
+
+func (o *or) Respond() ([]kyber.Scalar, error) {
+	if o.cs == nil || o.real < 0 || o.cs[o.real] == nil {
+		return nil, errors.New("sigma: response before commitment and challenge")
+	}
+	s, err := o.ps[o.real].Respond()
+	if err != nil {
+		return nil, err
+	}
+	o.responses[o.real] = s
+	response := append([]kyber.Scalar{}, o.cs...)
+	for _, s := range o.responses {
+		response = append(response, s...)
+	}
+	o.cs = nil
+	return response, nil
+}
+
+func (o *or) Verify(commit []kyber.Point, c kyber.Scalar, response []kyber.Scalar) error {
+	nc, nr := o.Len()
+	if len(commit) != nc || len(response) != nr {
+		return errInvalidProof
+	}
+	cs, response := response[:len(o.ps)], response[len(o.ps):]
+	sum := o.suite.Scalar().Zero()
+	for i, p := range o.ps {
+		sum.Add(sum, cs[i])
+		nc, nr := p.Len()
+		if err := p.Verify(commit[:nc], cs[i], response[:nr]); err != nil {
+			return err
+		}
+		commit, response = commit[nc:], response[nr:]
+	}
+	if !sum.Equal(c) {
+		return errInvalidProof
+	}
+	return nil
+}
+
+func (o *or) Simulate(c kyber.Scalar) ([]kyber.Point, []kyber.Scalar, error) {
+	var commit []kyber.Point
+	var responses []kyber.Scalar
+	cs := make([]kyber.Scalar, len(o.ps))
+	last := o.suite.Scalar().Set(c)
+	for i, p := range o.ps {
+		if i < len(o.ps)-1 {
+			cs[i] = o.suite.Scalar().Pick(o.suite.RandomStream())
+			last.Sub(last, cs[i])
+		} else {
+			cs[i] = last
+		}
+		t, s, err := p.Simulate(cs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		commit, responses = append(commit, t...), append(responses, s...)
+	}
+	return commit, append(cs, responses...), nil
+}
+
+func (o *or) Len() (int, int) {
+	nc, nr := 0, len(o.ps)
+	for _, p := range o.ps {
+		c, r := p.Len()
+		nc, nr = nc+c, nr+r
+	}
+	return nc, nr
+}
+
+func (o *or) Public() []kyber.Point {
+	var public []kyber.Point
+	for _, p := range o.ps {
+		public = append(public, p.Public()...)
+	}
+	return public
+}
@@ -0,0 +1,124 @@
+package sigma
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func keyPair() (kyber.Scalar, kyber.Point) {
+	x := suite.Scalar().Pick(suite.RandomStream())
+	return x, suite.Point().Mul(x, nil)
+}
+
+func TestDLogInteractive(t *testing.T) {
+	x, X := keyPair()
+	prover := DLog(suite, nil, X, x)
+	verifier := DLog(suite, nil, X, nil)
+
+	commit, err := prover.Commit()
+	require.Nil(t, err)
+	c := suite.Scalar().Pick(suite.RandomStream())
+	prover.Challenge(c)
+	response, err := prover.Respond()
+	require.Nil(t, err)
+	require.Nil(t, verifier.Verify(commit, c, response))
+	require.Error(t, verifier.Verify(commit, suite.Scalar().One(), response))
+
+	// a verifier cannot prove, but can simulate
+	_, err = verifier.Commit()
+	require.Equal(t, ErrNoWitness, err)
+	commit, response, err = verifier.Simulate(c)
+	require.Nil(t, err)
+	require.Nil(t, verifier.Verify(commit, c, response))
+}
+
+func TestAND(t *testing.T) {
+	x1, X1 := keyPair()
+	x2, X2 := keyPair()
+	H := suite.Point().Pick(suite.XOF([]byte("H")))
+	Y2 := suite.Point().Mul(x2, H)
+	ctx := []byte("and")
+
+	prover := AND(DLog(suite, nil, X1, x1), DLog(suite, H, Y2, x2))
+	proof, err := Prove(suite, prover, ctx)
+	require.Nil(t, err)
+	require.Len(t, proof.Commit, 2)
+	require.Len(t, proof.Response, 2)
+
+	verifier := AND(DLog(suite, nil, X1, nil), DLog(suite, H, Y2, nil))
+	require.Nil(t, Verify(suite, verifier, ctx, proof))
+	require.Error(t, Verify(suite, verifier, []byte("other"), proof))
+	require.Error(t, Verify(suite, AND(DLog(suite, nil, X1, nil), DLog(suite, nil, X2, nil)), ctx, proof))
+
+	// both witnesses are needed
+	_, err = Prove(suite, AND(DLog(suite, nil, X1, x1), DLog(suite, nil, X2, nil)), ctx)
+	require.Equal(t, ErrNoWitness, err)
+}
+
+func TestOR(t *testing.T) {
+	_, X1 := keyPair()
+	x2, X2 := keyPair()
+	_, X3 := keyPair()
+	ctx := []byte("or")
+	verifier := OR(suite, DLog(suite, nil, X1, nil), DLog(suite, nil, X2, nil), DLog(suite, nil, X3, nil))
+
+	// only the witness of the second statement is known
+	prover := OR(suite, DLog(suite, nil, X1, nil), DLog(suite, nil, X2, x2), DLog(suite, nil, X3, nil))
+	proof, err := Prove(suite, prover, ctx)
+	require.Nil(t, err)
+	require.Nil(t, Verify(suite, verifier, ctx, proof))
+
+	// tampering with one of the branch challenges breaks the sum
+	proof.Response[0] = suite.Scalar().Add(proof.Response[0], suite.Scalar().One())
+	require.Error(t, Verify(suite, verifier, ctx, proof))
+
+	_, err = Prove(suite, verifier, ctx)
+	require.Equal(t, ErrNoWitness, err)
+}
+
+func TestNested(t *testing.T) {
+	x1, X1 := keyPair()
+	_, X2 := keyPair()
+	x3, X3 := keyPair()
+	ctx := []byte("nested")
+
+	// x1 AND (x2 OR x3), knowing x1 and x3
+	prover := AND(DLog(suite, nil, X1, x1), OR(suite, DLog(suite, nil, X2, nil), DLog(suite, nil, X3, x3)))
+	proof, err := Prove(suite, prover, ctx)
+	require.Nil(t, err)
+	verifier := AND(DLog(suite, nil, X1, nil), OR(suite, DLog(suite, nil, X2, nil), DLog(suite, nil, X3, nil)))
+	require.Nil(t, Verify(suite, verifier, ctx, proof))
+
+	// (x1 AND x2) OR x3, knowing x3 only
+	prover = OR(suite, AND(DLog(suite, nil, X1, nil), DLog(suite, nil, X2, nil)), DLog(suite, nil, X3, x3))
+	proof, err = Prove(suite, prover, ctx)
+	require.Nil(t, err)
+	verifier = OR(suite, AND(DLog(suite, nil, X1, nil), DLog(suite, nil, X2, nil)), DLog(suite, nil, X3, nil))
+	require.Nil(t, Verify(suite, verifier, ctx, proof))
+
+	// simulated transcripts of compositions are accepted
+	c := suite.Scalar().Pick(suite.RandomStream())
+	commit, response, err := verifier.Simulate(c)
+	require.Nil(t, err)
+	require.Nil(t, verifier.Verify(commit, c, response))
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	x, X := keyPair()
+	ctx := []byte("malformed")
+	proof, err := Prove(suite, DLog(suite, nil, X, x), ctx)
+	require.Nil(t, err)
+	verifier := DLog(suite, nil, X, nil)
+	require.Nil(t, Verify(suite, verifier, ctx, proof))
+
+	require.Equal(t, errInvalidProof, Verify(suite, verifier, ctx, nil))
+	require.Equal(t, errInvalidProof, Verify(suite, verifier, ctx, &Proof{}))
+	require.Equal(t, errInvalidProof, Verify(suite, verifier, ctx, &Proof{[]kyber.Point{nil}, proof.Response}))
+	require.Equal(t, errInvalidProof, Verify(suite, verifier, ctx, &Proof{proof.Commit, []kyber.Scalar{nil}}))
+	require.Equal(t, errInvalidProof, Verify(suite, verifier, ctx, &Proof{proof.Commit, nil}))
+}
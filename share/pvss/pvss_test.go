@@ -256,3 +256,44 @@ func TestPVSSBatch(test *testing.T) {
 	require.True(test, suite.Point().Mul(s1, nil).Equal(S1))
 	require.True(test, suite.Point().Mul(s2, nil).Equal(S2))
 }
+
+func TestPVSSCorruptedShare(test *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	G := suite.Point().Base()
+	H := suite.Point().Pick(suite.XOF([]byte("H")))
+	n := 7
+	t := 4
+	x := make([]kyber.Scalar, n) // trustee private keys
+	X := make([]kyber.Point, n)  // trustee public keys
+	for i := 0; i < n; i++ {
+		x[i] = suite.Scalar().Pick(suite.RandomStream())
+		X[i] = suite.Point().Mul(x[i], nil)
+	}
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	encShares, pubPoly, err := EncShares(suite, H, X, secret, t)
+	require.Equal(test, err, nil)
+
+	sH := make([]kyber.Point, n)
+	for i := 0; i < n; i++ {
+		sH[i] = pubPoly.Eval(encShares[i].S.I).V
+		require.Equal(test, VerifyEncShare(suite, H, X[i], sH[i], encShares[i]), nil)
+	}
+
+	// A corrupted share is caught by anyone, without decrypting it
+	encShares[3].S.V = suite.Point().Add(encShares[3].S.V, G)
+	require.Equal(test, VerifyEncShare(suite, H, X[3], sH[3], encShares[3]), errorEncVerification)
+	K, E, err := VerifyEncShareBatch(suite, H, X, sH, encShares)
+	require.Equal(test, err, nil)
+	require.Equal(test, n-1, len(E))
+
+	var D []*PubVerShare
+	for i, e := range E {
+		j := e.S.I
+		ds, err := DecShare(suite, H, K[i], sH[j], x[j], e)
+		require.Equal(test, err, nil)
+		D = append(D, ds)
+	}
+	recovered, err := RecoverSecret(suite, G, K, E, D, t, n)
+	require.Equal(test, err, nil)
+	require.True(test, suite.Point().Mul(secret, nil).Equal(recovered))
+}
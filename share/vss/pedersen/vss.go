@@ -191,7 +191,7 @@ func (d *Dealer) EncryptedDeal(i int) (*EncryptedDeal, error) {
 	}
 	// AES128-GCM
 	pre := dhExchange(d.suite, dhSecret, vPub)
-	gcm, err := newAEAD(d.suite.Hash, pre, d.hkdfContext)
+	gcm, err := newAEAD(d.suite, pre, d.hkdfContext)
 	if err != nil {
 		return nil, err
 	}
@@ -409,7 +409,7 @@ func (v *Verifier) decryptDeal(e *EncryptedDeal) (*Deal, error) {
 		return nil, err
 	}
 	pre := dhExchange(v.suite, v.longterm, dhKey)
-	gcm, err := newAEAD(v.suite.Hash, pre, v.hkdfContext)
+	gcm, err := newAEAD(v.suite, pre, v.hkdfContext)
 	if err != nil {
 		return nil, err
 	}
@@ -3,11 +3,9 @@ package vss
 import (
 	"crypto/aes"
 	"crypto/cipher"
-	"hash"
 
 	"github.com/dedis/kyber"
-
-	"golang.org/x/crypto/hkdf"
+	"github.com/dedis/kyber/util/kdf"
 )
 
 // dhExchange computes the shared key from a private key and a public key
@@ -20,14 +18,9 @@ func dhExchange(suite Suite, ownPrivate kyber.Scalar, remotePublic kyber.Point)
 var sharedKeyLength = 32
 
 // newAEAD returns the AEAD cipher to be use to encrypt a share
-func newAEAD(fn func() hash.Hash, preSharedKey kyber.Point, context []byte) (cipher.AEAD, error) {
+func newAEAD(suite Suite, preSharedKey kyber.Point, context []byte) (cipher.AEAD, error) {
 	preBuff, _ := preSharedKey.MarshalBinary()
-	reader := hkdf.New(fn, preBuff, nil, context)
-
-	sharedKey := make([]byte, sharedKeyLength)
-	if _, err := reader.Read(sharedKey); err != nil {
-		return nil, err
-	}
+	sharedKey := kdf.HKDF(suite, preBuff, nil, context, sharedKeyLength)
 	block, err := aes.NewCipher(sharedKey)
 	if err != nil {
 		return nil, err
@@ -237,6 +237,40 @@ func ExampleSign_linkable() {
 
 var benchMessage = []byte("Hello World!")
 
+func TestSignRingSizes(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	msg := []byte("Hello ring")
+	for _, n := range []int{1, 2, 10} {
+		X, x := benchGenKeys(suite, n)
+		mine := n / 2
+		X[0], X[mine] = X[mine], X[0]
+
+		for _, scope := range [][]byte{nil, []byte("scope")} {
+			sig := Sign(suite, msg, Set(X), scope, mine, x)
+			if _, err := Verify(suite, msg, Set(X), scope, sig); err != nil {
+				t.Fatalf("ring of size %d: %v", n, err)
+			}
+			if _, err := Verify(suite, []byte("Hello world"), Set(X), scope, sig); err == nil {
+				t.Fatalf("ring of size %d: signature valid for another message", n)
+			}
+
+			// removing any public key from the ring invalidates the signature
+			for i := 0; i < n && n > 1; i++ {
+				ring := append(append([]kyber.Point{}, X[:i]...), X[i+1:]...)
+				if _, err := Verify(suite, msg, Set(ring), scope, sig); err == nil {
+					t.Fatalf("ring of size %d: signature valid without key %d", n, i)
+				}
+			}
+			// replacing it too
+			ring := append([]kyber.Point{}, X...)
+			ring[mine] = suite.Point().Pick(random.New())
+			if _, err := Verify(suite, msg, Set(ring), scope, sig); err == nil {
+				t.Fatalf("ring of size %d: signature valid with another key", n)
+			}
+		}
+	}
+}
+
 var benchPubEd25519, benchPriEd25519 = benchGenKeysEd25519(100)
 var benchSig1Ed25519 = benchGenSigEd25519(1)
 var benchSig10Ed25519 = benchGenSigEd25519(10)
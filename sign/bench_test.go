@@ -0,0 +1,217 @@
+package sign_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/pairing/bn256"
+	"github.com/dedis/kyber/share"
+	"github.com/dedis/kyber/sign/bls"
+	"github.com/dedis/kyber/sign/eddsa"
+	"github.com/dedis/kyber/sign/musig2"
+	"github.com/dedis/kyber/sign/schnorr"
+	"github.com/dedis/kyber/sign/tbls"
+	"github.com/dedis/kyber/sign/tschnorr"
+)
+
+// This file compares the signature schemes of kyber. Run it with
+//
+//	go test -run XXX -bench . ./sign/
+
+var (
+	suite        = edwards25519.NewBlakeSHA256Ed25519()
+	pairingSuite = bn256.NewSuite()
+)
+
+// the threshold schemes use t-of-n sharings
+const (
+	thr = 3
+	n   = 5
+)
+
+var msgSizes = []int{32, 1 << 10, 1 << 20}
+
+type signer struct {
+	sign   func(msg []byte) ([]byte, error)
+	verify func(msg, sig []byte) error
+}
+
+type scheme struct {
+	name  string
+	setup func() (*signer, error)
+}
+
+var schemes = []scheme{
+	{"Schnorr", newSchnorr},
+	{"EdDSA", newEdDSA},
+	{"BLS", newBLS},
+	{"TBLS", newTBLS},
+	{"TSchnorr", newTSchnorr},
+	{"MuSig2", newMuSig2},
+}
+
+func newSchnorr() (*signer, error) {
+	x := suite.Scalar().Pick(suite.RandomStream())
+	X := suite.Point().Mul(x, nil)
+	return &signer{
+		sign:   func(msg []byte) ([]byte, error) { return schnorr.Sign(suite, x, msg) },
+		verify: func(msg, sig []byte) error { return schnorr.Verify(suite, X, msg, sig) },
+	}, nil
+}
+
+func newEdDSA() (*signer, error) {
+	ed := eddsa.NewEdDSA(suite.RandomStream())
+	return &signer{
+		sign:   ed.Sign,
+		verify: func(msg, sig []byte) error { return eddsa.Verify(ed.Public, msg, sig) },
+	}, nil
+}
+
+func newBLS() (*signer, error) {
+	x, X := bls.NewKeyPair(pairingSuite, pairingSuite.RandomStream())
+	return &signer{
+		sign:   func(msg []byte) ([]byte, error) { return bls.Sign(pairingSuite, x, msg) },
+		verify: func(msg, sig []byte) error { return bls.Verify(pairingSuite, X, msg, sig) },
+	}, nil
+}
+
+// newTBLS signs with thr of the n shares and recovers the signature.
+func newTBLS() (*signer, error) {
+	g2 := pairingSuite.G2()
+	secret := g2.Scalar().Pick(pairingSuite.RandomStream())
+	priPoly := share.NewPriPoly(g2, thr, secret, pairingSuite.RandomStream())
+	pubPoly := priPoly.Commit(g2.Point().Base())
+	shares := priPoly.Shares(n)
+	return &signer{
+		sign: func(msg []byte) ([]byte, error) {
+			sigs := make([][]byte, thr)
+			for i := range sigs {
+				var err error
+				if sigs[i], err = tbls.Sign(pairingSuite, shares[i], msg); err != nil {
+					return nil, err
+				}
+			}
+			return tbls.Recover(pairingSuite, pubPoly, msg, sigs, thr, n)
+		},
+		verify: func(msg, sig []byte) error {
+			return bls.Verify(pairingSuite, pubPoly.Commit(), msg, sig)
+		},
+	}, nil
+}
+
+// newTSchnorr runs the nonce generation and signs with thr of the n shares.
+func newTSchnorr() (*signer, error) {
+	keys, public, err := tschnorr.DealerSetup(suite, thr, n)
+	if err != nil {
+		return nil, err
+	}
+	return &signer{
+		sign: func(msg []byte) ([]byte, error) {
+			nonces, noncePoly, err := tschnorr.DealerSetup(suite, thr, n)
+			if err != nil {
+				return nil, err
+			}
+			partials := make([]*share.PriShare, thr)
+			for i := range partials {
+				if partials[i], err = tschnorr.SignShare(suite, keys[i], nonces[i], msg); err != nil {
+					return nil, err
+				}
+			}
+			return tschnorr.CombineShares(suite, public, noncePoly, msg, partials, thr, n)
+		},
+		verify: func(msg, sig []byte) error {
+			return tschnorr.Verify(suite, public.Commit(), msg, sig)
+		},
+	}, nil
+}
+
+// newMuSig2 runs the three rounds among n signers.
+func newMuSig2() (*signer, error) {
+	privates := make([]kyber.Scalar, n)
+	publics := make([]kyber.Point, n)
+	for i := range privates {
+		privates[i] = suite.Scalar().Pick(suite.RandomStream())
+		publics[i] = suite.Point().Mul(privates[i], nil)
+	}
+	combined, err := musig2.AggregateKey(suite, publics)
+	if err != nil {
+		return nil, err
+	}
+	return &signer{
+		sign: func(msg []byte) ([]byte, error) {
+			signers := make([]*musig2.Signer, n)
+			commitments := make([][]byte, n)
+			for i := range signers {
+				var err error
+				if signers[i], err = musig2.NewSigner(suite, privates[i], publics); err != nil {
+					return nil, err
+				}
+				if commitments[i], err = signers[i].Round1(); err != nil {
+					return nil, err
+				}
+			}
+			nonces := make([]*musig2.Nonces, n)
+			for i, s := range signers {
+				var err error
+				if nonces[i], err = s.Round2(commitments); err != nil {
+					return nil, err
+				}
+			}
+			partials := make([][]byte, n)
+			for i, s := range signers {
+				var err error
+				if partials[i], err = s.Round3(nonces, msg); err != nil {
+					return nil, err
+				}
+			}
+			return musig2.Aggregate(suite, partials, combined, msg)
+		},
+		verify: func(msg, sig []byte) error { return schnorr.Verify(suite, combined, msg, sig) },
+	}, nil
+}
+
+// benchmark runs f for every scheme and message size, with a signer and a
+// valid signature of the message.
+func benchmark(b *testing.B, f func(b *testing.B, s *signer, msg, sig []byte)) {
+	for _, scheme := range schemes {
+		for _, size := range msgSizes {
+			b.Run(fmt.Sprintf("%s/%dB", scheme.name, size), func(b *testing.B) {
+				s, err := scheme.setup()
+				if err != nil {
+					b.Fatal(err)
+				}
+				msg := make([]byte, size)
+				sig, err := s.sign(msg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				f(b, s, msg, sig)
+				b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ops/s")
+			})
+		}
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	benchmark(b, func(b *testing.B, s *signer, msg, _ []byte) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.sign(msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkVerify(b *testing.B) {
+	benchmark(b, func(b *testing.B, s *signer, msg, sig []byte) {
+		for i := 0; i < b.N; i++ {
+			if err := s.verify(msg, sig); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
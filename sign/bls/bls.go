@@ -49,6 +49,55 @@ func Verify(suite pairing.Suite, X kyber.Point, msg, sig []byte) error {
 	return nil
 }
 
+// AggregateSignatures combines the signatures sigs into the single signature
+// S = S1 + ... + Sn, which is a point on curve G1 like each of them.
+func AggregateSignatures(suite pairing.Suite, sigs ...[]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls: no signature to aggregate")
+	}
+	agg := suite.G1().Point().Null()
+	for _, sig := range sigs {
+		s := suite.G1().Point()
+		if err := s.UnmarshalBinary(sig); err != nil {
+			return nil, err
+		}
+		agg.Add(agg, s)
+	}
+	return agg.MarshalBinary()
+}
+
+// AggregateVerify checks the aggregate signature S of the messages msgs, each
+// signed by the private key of the public key at the same position in
+// publics, by verifying that e(H(m1), X1) + ... + e(H(mn), Xn) == e(S, B2).
+// The messages must be distinct, otherwise an attacker could choose a public
+// key that cancels the others, as described in section 3.1 of the IETF BLS
+// signature draft.
+func AggregateVerify(suite pairing.Suite, publics []kyber.Point, msgs [][]byte, sig []byte) error {
+	if len(publics) == 0 || len(publics) != len(msgs) {
+		return errors.New("bls: invalid number of public keys or messages")
+	}
+	seen := make(map[string]bool, len(msgs))
+	for _, msg := range msgs {
+		if seen[string(msg)] {
+			return errors.New("bls: messages are not distinct")
+		}
+		seen[string(msg)] = true
+	}
+	left := suite.GT().Point().Null()
+	for i, msg := range msgs {
+		left.Add(left, suite.Pair(hashToPoint(suite, msg), publics[i]))
+	}
+	s := suite.G1().Point()
+	if err := s.UnmarshalBinary(sig); err != nil {
+		return err
+	}
+	right := suite.Pair(s, suite.G2().Point().Base())
+	if !left.Equal(right) {
+		return errors.New("bls: invalid signature")
+	}
+	return nil
+}
+
 // hashToPoint hashes a message to a point on curve G1. XXX: This should be replaced
 // eventually by a proper hash-to-point mapping like Elligator.
 func hashToPoint(suite pairing.Suite, msg []byte) kyber.Point {
@@ -3,6 +3,9 @@ package bls
 import (
 	"testing"
 
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing"
+	"github.com/dedis/kyber/pairing/bls12381"
 	"github.com/dedis/kyber/pairing/bn256"
 	"github.com/dedis/kyber/util/random"
 	"github.com/stretchr/testify/require"
@@ -41,3 +44,45 @@ func TestBLSFailKey(t *testing.T) {
 		t.Fatal("bls: verification succeeded unexpectedly")
 	}
 }
+
+func TestBLS12381(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bls12381.NewSuite()
+	private, public := NewKeyPair(suite, random.New())
+	sig, err := Sign(suite, private, msg)
+	require.Nil(t, err)
+	require.Nil(t, Verify(suite, public, msg, sig))
+	sig[len(sig)-1] ^= 0x01
+	require.NotNil(t, Verify(suite, public, msg, sig))
+}
+
+func TestBLSAggregate(t *testing.T) {
+	for _, suite := range []pairing.Suite{bn256.NewSuite(), bls12381.NewSuite()} {
+		msgs := [][]byte{[]byte("first"), []byte("second"), []byte("third")}
+		publics := make([]kyber.Point, len(msgs))
+		sigs := make([][]byte, len(msgs))
+		for i, msg := range msgs {
+			var private kyber.Scalar
+			private, publics[i] = NewKeyPair(suite, random.New())
+			var err error
+			sigs[i], err = Sign(suite, private, msg)
+			require.Nil(t, err)
+		}
+		agg, err := AggregateSignatures(suite, sigs...)
+		require.Nil(t, err)
+		require.Nil(t, AggregateVerify(suite, publics, msgs, agg))
+
+		// a missing signature, swapped keys or repeated messages fail
+		partial, err := AggregateSignatures(suite, sigs[:2]...)
+		require.Nil(t, err)
+		require.NotNil(t, AggregateVerify(suite, publics, msgs, partial))
+		swapped := []kyber.Point{publics[1], publics[0], publics[2]}
+		require.NotNil(t, AggregateVerify(suite, swapped, msgs, agg))
+		repeated := [][]byte{msgs[0], msgs[0], msgs[2]}
+		require.NotNil(t, AggregateVerify(suite, publics, repeated, agg))
+		require.NotNil(t, AggregateVerify(suite, publics[:2], msgs, agg))
+
+		_, err = AggregateSignatures(suite)
+		require.NotNil(t, err)
+	}
+}
@@ -0,0 +1,73 @@
+// +build go1.27
+
+package dilithium
+
+import (
+	"crypto/mldsa"
+	"errors"
+)
+
+// PrivateKey is an ML-DSA-65 private key.
+type PrivateKey = mldsa.PrivateKey
+
+// PublicKey is an ML-DSA-65 public key.
+type PublicKey = mldsa.PublicKey
+
+const (
+	// SeedSize is the size of the seed encoding of a private key.
+	SeedSize = mldsa.PrivateKeySize
+	// PublicKeySize is the size of the encoding of a public key.
+	PublicKeySize = mldsa.MLDSA65PublicKeySize
+	// SignatureSize is the size of a signature.
+	SignatureSize = mldsa.MLDSA65SignatureSize
+)
+
+// GenerateKey returns a fresh private key.
+func GenerateKey() (*PrivateKey, error) {
+	return mldsa.GenerateKey(mldsa.MLDSA65())
+}
+
+// NewPrivateKey returns the private key of the given seed, as returned by
+// the Bytes method of the private keys.
+func NewPrivateKey(seed []byte) (*PrivateKey, error) {
+	return mldsa.NewPrivateKey(mldsa.MLDSA65(), seed)
+}
+
+// NewPublicKey decodes a public key, as returned by the Bytes method of the
+// public keys.
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	return mldsa.NewPublicKey(mldsa.MLDSA65(), b)
+}
+
+// Sign returns a signature of msg. The signature is hedged: it depends on
+// fresh randomness as well as on the key and the message.
+func Sign(priv *PrivateKey, msg []byte) ([]byte, error) {
+	if priv == nil {
+		return nil, errors.New("dilithium: no private key")
+	}
+	return priv.Sign(nil, msg, nil)
+}
+
+// SignDeterministic is like Sign, but the signature only depends on the key
+// and the message, so that signing does not need a random source.
+func SignDeterministic(priv *PrivateKey, msg []byte) ([]byte, error) {
+	if priv == nil {
+		return nil, errors.New("dilithium: no private key")
+	}
+	return priv.SignDeterministic(msg, nil)
+}
+
+// Verify verifies a signature of msg. It returns nil iff the signature is
+// valid.
+func Verify(pub *PublicKey, msg, sig []byte) error {
+	if pub == nil {
+		return errors.New("dilithium: no public key")
+	}
+	if len(sig) != SignatureSize {
+		return errors.New("dilithium: invalid signature length")
+	}
+	if err := mldsa.Verify(pub, msg, sig, nil); err != nil {
+		return errors.New("dilithium: invalid signature")
+	}
+	return nil
+}
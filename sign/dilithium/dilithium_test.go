@@ -0,0 +1,69 @@
+// +build go1.27
+
+package dilithium
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestDilithium(t *testing.T) {
+	msg := []byte("Hello Dilithium")
+	priv, err := GenerateKey()
+	require.Nil(t, err)
+	sig, err := Sign(priv, msg)
+	require.Nil(t, err)
+	require.Len(t, sig, SignatureSize)
+	require.Nil(t, Verify(priv.PublicKey(), msg, sig))
+	require.Error(t, Verify(priv.PublicKey(), []byte("Hello Schnorr"), sig))
+
+	sig[0] ^= 1
+	require.Error(t, Verify(priv.PublicKey(), msg, sig))
+	require.Error(t, Verify(priv.PublicKey(), msg, sig[1:]))
+
+	other, err := GenerateKey()
+	require.Nil(t, err)
+	sig, err = Sign(other, msg)
+	require.Nil(t, err)
+	require.Error(t, Verify(priv.PublicKey(), msg, sig))
+
+	// keys round-trip through their encodings
+	priv2, err := NewPrivateKey(priv.Bytes())
+	require.Nil(t, err)
+	require.True(t, priv.Equal(priv2))
+	pub, err := NewPublicKey(priv.PublicKey().Bytes())
+	require.Nil(t, err)
+	require.True(t, pub.Equal(priv.PublicKey()))
+	_, err = NewPublicKey(make([]byte, PublicKeySize-1))
+	require.Error(t, err)
+}
+
+// TestDilithiumAccumulated checks the accumulated ML-DSA-65 vector of the
+// C2SP test vectors: the SHAKE128 hash of the public keys and deterministic
+// signatures of the empty message of 100 keys, with seeds drawn from SHAKE128.
+func TestDilithiumAccumulated(t *testing.T) {
+	s := sha3.NewShake128()
+	o := sha3.NewShake128()
+	seed := make([]byte, SeedSize)
+	for i := 0; i < 100; i++ {
+		s.Read(seed)
+		priv, err := NewPrivateKey(seed)
+		require.Nil(t, err)
+		pub := priv.PublicKey().Bytes()
+		o.Write(pub)
+		sig, err := SignDeterministic(priv, []byte{})
+		require.Nil(t, err)
+		o.Write(sig)
+
+		decoded, err := NewPublicKey(pub)
+		require.Nil(t, err)
+		require.Nil(t, Verify(decoded, []byte{}, sig))
+	}
+	sum := make([]byte, 32)
+	o.Read(sum)
+	require.Equal(t, "8358a1843220194417cadbc2651295cd8fc65125b5a5c1a239a16dc8b57ca199",
+		hex.EncodeToString(sum))
+}
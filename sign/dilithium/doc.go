@@ -0,0 +1,12 @@
+// Package dilithium implements the post-quantum CRYSTALS-Dilithium signature
+// scheme, in its ML-DSA-65 form standardized in FIPS 204, on top of the
+// crypto/mldsa package of the standard library.
+//
+// Sign and Verify follow the calling convention of the schnorr package so
+// that callers can switch schemes without changing call sites, but the keys
+// are not kyber points and scalars: a private key is a 32-byte seed, and
+// public keys and signatures are much larger than with elliptic curves.
+//
+// The package is empty unless built with Go 1.27 or newer, the first version
+// with crypto/mldsa.
+package dilithium
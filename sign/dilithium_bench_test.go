@@ -0,0 +1,20 @@
+// +build go1.27
+
+package sign_test
+
+import "github.com/dedis/kyber/sign/dilithium"
+
+func init() {
+	schemes = append(schemes, scheme{"Dilithium", newDilithium})
+}
+
+func newDilithium() (*signer, error) {
+	priv, err := dilithium.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &signer{
+		sign:   func(msg []byte) ([]byte, error) { return dilithium.Sign(priv, msg) },
+		verify: func(msg, sig []byte) error { return dilithium.Verify(priv.PublicKey(), msg, sig) },
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/dedis/kyber"
 	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/ctcompare"
 	"github.com/dedis/kyber/util/random"
 )
 
@@ -156,7 +157,7 @@ func Verify(public kyber.Point, msg, sig []byte) error {
 	hA := group.Point().Mul(h, public)
 	RhA := group.Point().Add(R, hA)
 
-	if !RhA.Equal(S) {
+	if !ctcompare.PointEqual(RhA, S) {
 		return errors.New("reconstructed S is not equal to signature")
 	}
 	return nil
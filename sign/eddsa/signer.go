@@ -0,0 +1,45 @@
+package eddsa
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"io"
+)
+
+// Ed25519Signer wraps an EdDSA key pair to implement the crypto.Signer
+// interface of the standard library, so that it can be used by packages such
+// as crypto/x509 and crypto/tls. Its signatures are the same as those of
+// crypto/ed25519. There is no crypto.Decrypter counterpart since Ed25519 keys
+// cannot decrypt.
+type Ed25519Signer struct {
+	key *EdDSA
+}
+
+// NewEd25519Signer returns a crypto.Signer signing with the key pair e.
+func NewEd25519Signer(e *EdDSA) *Ed25519Signer {
+	return &Ed25519Signer{key: e}
+}
+
+// Public returns the public key as an ed25519.PublicKey.
+func (s *Ed25519Signer) Public() crypto.PublicKey {
+	buf, err := s.key.Public.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return ed25519.PublicKey(buf)
+}
+
+// Sign signs the message, which like for ed25519.PrivateKey must not be
+// hashed: opts.HashFunc() must return zero. The rand argument is ignored
+// since EdDSA signatures are deterministic. The Ed25519ph and Ed25519ctx
+// variants are not supported.
+func (s *Ed25519Signer) Sign(rand io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("eddsa: cannot sign hashed messages")
+	}
+	if o, ok := opts.(*ed25519.Options); ok && o.Context != "" {
+		return nil, errors.New("eddsa: contexts are not supported")
+	}
+	return s.key.Sign(message)
+}
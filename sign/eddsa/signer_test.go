@@ -0,0 +1,54 @@
+package eddsa
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEd25519Signer(t *testing.T) {
+	signer := NewEd25519Signer(NewEdDSA(random.New()))
+	var _ crypto.Signer = signer
+	public, ok := signer.Public().(ed25519.PublicKey)
+	require.True(t, ok)
+
+	msg := []byte("Hello crypto.Signer")
+	sig, err := signer.Sign(nil, msg, crypto.Hash(0))
+	require.Nil(t, err)
+	require.True(t, ed25519.Verify(public, msg, sig))
+
+	digest := sha512.Sum512(msg)
+	_, err = signer.Sign(nil, digest[:], crypto.SHA512)
+	require.Error(t, err)
+	_, err = signer.Sign(nil, msg, &ed25519.Options{Context: "context"})
+	require.Error(t, err)
+}
+
+func TestEd25519SignerCertificate(t *testing.T) {
+	signer := NewEd25519Signer(NewEdDSA(random.New()))
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kyber"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	require.Equal(t, x509.Ed25519, cert.PublicKeyAlgorithm)
+	require.Equal(t, signer.Public(), cert.PublicKey)
+	require.Nil(t, cert.CheckSignatureFrom(cert))
+}
@@ -0,0 +1,377 @@
+// Package frost implements the FROST(Ed25519, SHA-512) threshold Schnorr
+// signature scheme of RFC 9591, "The Flexible Round-Optimized Schnorr
+// Threshold (FROST) Protocol for Two-Round Schnorr Signatures".
+//
+// A trusted dealer splits a signing key among n participants with Setup. To
+// sign a message, t of them each run Round1 to pick a pair of nonces and
+// publish the commitment to them, then Round2 over the commitments of all
+// signers to produce a signature share. Aggregate combines the shares into a
+// signature that is a plain Ed25519 signature under the group public key.
+//
+// The nonces returned by Round1 must be used for a single call to Round2 and
+// then discarded: signing twice with the same nonces reveals the share of the
+// private key.
+package frost
+
+import (
+	"crypto/sha512"
+	"errors"
+	"sort"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/share"
+	"github.com/dedis/kyber/sign/eddsa"
+	"github.com/dedis/kyber/sign/schnorr"
+	"github.com/dedis/kyber/util/random"
+)
+
+// Suite represents the set of functionalities needed by the package frost.
+// Only the edwards25519 group is supported.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+var group = new(edwards25519.Curve)
+
+// contextString is the context string of FROST(Ed25519, SHA-512).
+const contextString = "FROST-ED25519-SHA512-v1"
+
+// ParticipantShare is the key material of a participant: its identifier, its
+// share of the private key, the corresponding public key share and the group
+// public key.
+type ParticipantShare struct {
+	Identifier  int
+	Secret      kyber.Scalar
+	Public      kyber.Point
+	GroupPublic kyber.Point
+
+	suite Suite
+}
+
+// Nonce is the pair of secret nonces of a participant for one signature.
+type Nonce struct {
+	Hiding, Binding kyber.Scalar
+}
+
+// NonceCommitment is the public commitment of a participant to its nonces.
+type NonceCommitment struct {
+	Identifier      int
+	Hiding, Binding kyber.Point
+}
+
+// SigShare is the signature share of a participant.
+type SigShare struct {
+	Identifier int
+	Z          kyber.Scalar
+}
+
+var (
+	errSuite      = errors.New("frost: only the Ed25519 group is supported")
+	errThreshold  = errors.New("frost: invalid threshold")
+	errCommitment = errors.New("frost: invalid list of commitments")
+	errShares     = errors.New("frost: invalid list of signature shares")
+)
+
+// Setup runs the trusted dealer key generation of RFC 9591 appendix C: it
+// picks a random signing key, splits it with a polynomial of degree t-1 in n
+// shares, of identifiers 1 to n, and returns the shares with the group
+// public key.
+func Setup(suite Suite, t, n int) ([]*ParticipantShare, kyber.Point, error) {
+	if suite.String() != group.String() {
+		return nil, nil, errSuite
+	}
+	if t < 1 || t > n {
+		return nil, nil, errThreshold
+	}
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	poly := share.NewPriPoly(suite, t, secret, suite.RandomStream())
+	return newShares(suite, poly, n), suite.Point().Mul(secret, nil), nil
+}
+
+func newShares(suite Suite, poly *share.PriPoly, n int) []*ParticipantShare {
+	groupPublic := suite.Point().Mul(poly.Secret(), nil)
+	shares := make([]*ParticipantShare, n)
+	for i, s := range poly.Shares(n) {
+		shares[i] = &ParticipantShare{
+			// share.PriPoly evaluates share i at i+1
+			Identifier:  s.I + 1,
+			Secret:      s.V,
+			Public:      suite.Point().Mul(s.V, nil),
+			GroupPublic: groupPublic,
+			suite:       suite,
+		}
+	}
+	return shares
+}
+
+// Round1 picks the nonces of the participant for one signature and returns
+// them with the commitment to publish to the other signers.
+func Round1(share *ParticipantShare) (NonceCommitment, Nonce, error) {
+	var r [2][32]byte
+	random.Bytes(r[0][:], share.suite.RandomStream())
+	random.Bytes(r[1][:], share.suite.RandomStream())
+	nonce, err := newNonce(share, r[0][:], r[1][:])
+	if err != nil {
+		return NonceCommitment{}, Nonce{}, err
+	}
+	return NonceCommitment{
+		Identifier: share.Identifier,
+		Hiding:     group.Point().Mul(nonce.Hiding, nil),
+		Binding:    group.Point().Mul(nonce.Binding, nil),
+	}, nonce, nil
+}
+
+func newNonce(share *ParticipantShare, hidingRandom, bindingRandom []byte) (Nonce, error) {
+	hiding, err := nonceGenerate(hidingRandom, share.Secret)
+	if err != nil {
+		return Nonce{}, err
+	}
+	binding, err := nonceGenerate(bindingRandom, share.Secret)
+	if err != nil {
+		return Nonce{}, err
+	}
+	return Nonce{Hiding: hiding, Binding: binding}, nil
+}
+
+// Round2 returns the signature share of msg, given the nonce picked by
+// Round1 and the commitments of all the signers, including the participant.
+// The nonce must not be used again.
+func Round2(share *ParticipantShare, nonce Nonce, msg []byte, commitments []NonceCommitment) (SigShare, error) {
+	commitments, err := sortCommitments(commitments)
+	if err != nil {
+		return SigShare{}, err
+	}
+	var own *NonceCommitment
+	for i := range commitments {
+		if commitments[i].Identifier == share.Identifier {
+			own = &commitments[i]
+		}
+	}
+	if own == nil ||
+		!own.Hiding.Equal(group.Point().Mul(nonce.Hiding, nil)) ||
+		!own.Binding.Equal(group.Point().Mul(nonce.Binding, nil)) {
+		return SigShare{}, errCommitment
+	}
+
+	factors, err := bindingFactors(share.GroupPublic, commitments, msg)
+	if err != nil {
+		return SigShare{}, err
+	}
+	R := groupCommitment(commitments, factors)
+	c, err := challenge(R, share.GroupPublic, msg)
+	if err != nil {
+		return SigShare{}, err
+	}
+	lambda := interpolatingValue(commitments, share.Identifier)
+
+	// z = hiding + binding·rho + lambda·secret·c
+	z := group.Scalar().Mul(nonce.Binding, factors[share.Identifier])
+	z.Add(z, nonce.Hiding)
+	z.Add(z, group.Scalar().Mul(lambda, group.Scalar().Mul(share.Secret, c)))
+	return SigShare{Identifier: share.Identifier, Z: z}, nil
+}
+
+// Aggregate combines the signature shares of the signers into the signature
+// R || z of msg, and checks it against the group public key pub. The
+// commitments are those given to Round2.
+func Aggregate(sigShares []SigShare, msg []byte, pub kyber.Point, commitments []NonceCommitment) ([]byte, error) {
+	commitments, err := sortCommitments(commitments)
+	if err != nil {
+		return nil, err
+	}
+	if len(sigShares) != len(commitments) {
+		return nil, errShares
+	}
+	factors, err := bindingFactors(pub, commitments, msg)
+	if err != nil {
+		return nil, err
+	}
+	R := groupCommitment(commitments, factors)
+
+	z := group.Scalar().Zero()
+	seen := make(map[int]bool, len(sigShares))
+	for _, s := range sigShares {
+		if _, ok := factors[s.Identifier]; !ok || seen[s.Identifier] {
+			return nil, errShares
+		}
+		seen[s.Identifier] = true
+		z.Add(z, s.Z)
+	}
+
+	Rbuf, err := R.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	zbuf, err := z.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	sig := append(Rbuf, zbuf...)
+	if err := eddsa.Verify(pub, msg, sig); err != nil {
+		return nil, errors.New("frost: invalid signature shares")
+	}
+	return sig, nil
+}
+
+// VerifyShare checks the signature share of the participant with the given
+// public key share, so that Aggregate failing can be blamed on the
+// participants whose share is invalid.
+func VerifyShare(sigShare SigShare, public, groupPublic kyber.Point, msg []byte, commitments []NonceCommitment) error {
+	commitments, err := sortCommitments(commitments)
+	if err != nil {
+		return err
+	}
+	factors, err := bindingFactors(groupPublic, commitments, msg)
+	if err != nil {
+		return err
+	}
+	var own *NonceCommitment
+	for i := range commitments {
+		if commitments[i].Identifier == sigShare.Identifier {
+			own = &commitments[i]
+		}
+	}
+	if own == nil {
+		return errCommitment
+	}
+	R := groupCommitment(commitments, factors)
+	c, err := challenge(R, groupPublic, msg)
+	if err != nil {
+		return err
+	}
+	lambda := interpolatingValue(commitments, sigShare.Identifier)
+
+	// z·B == hiding + rho·binding + (c·lambda)·public
+	left := group.Point().Mul(sigShare.Z, nil)
+	right := group.Point().Mul(factors[sigShare.Identifier], own.Binding)
+	right.Add(right, own.Hiding)
+	right.Add(right, group.Point().Mul(group.Scalar().Mul(c, lambda), public))
+	if !left.Equal(right) {
+		return errors.New("frost: invalid signature share")
+	}
+	return nil
+}
+
+// sortCommitments returns the commitments sorted by identifier, and checks
+// that the identifiers are positive and distinct and, as section 5.3 of RFC
+// 9591 requires, that no hiding or binding commitment is the identity.
+func sortCommitments(commitments []NonceCommitment) ([]NonceCommitment, error) {
+	if len(commitments) == 0 {
+		return nil, errCommitment
+	}
+	sorted := append([]NonceCommitment{}, commitments...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Identifier < sorted[j].Identifier })
+	null := group.Point().Null()
+	for i, c := range sorted {
+		if c.Identifier < 1 || (i > 0 && c.Identifier == sorted[i-1].Identifier) {
+			return nil, errCommitment
+		}
+		if c.Hiding == nil || c.Binding == nil || c.Hiding.Equal(null) || c.Binding.Equal(null) {
+			return nil, errCommitment
+		}
+	}
+	return sorted, nil
+}
+
+// nonceGenerate implements section 4.1 of RFC 9591.
+func nonceGenerate(random []byte, secret kyber.Scalar) (kyber.Scalar, error) {
+	buf, err := secret.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return h3(append(append([]byte{}, random...), buf...)), nil
+}
+
+// bindingFactors implements compute_binding_factors of section 4.4 of RFC
+// 9591, mapping each identifier to its binding factor.
+func bindingFactors(groupPublic kyber.Point, commitments []NonceCommitment, msg []byte) (map[int]kyber.Scalar, error) {
+	prefix, err := groupPublic.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeCommitments(commitments)
+	if err != nil {
+		return nil, err
+	}
+	prefix = append(prefix, h4(msg)...)
+	prefix = append(prefix, h5(encoded)...)
+	factors := make(map[int]kyber.Scalar, len(commitments))
+	for _, c := range commitments {
+		id, err := identifier(c.Identifier).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		factors[c.Identifier] = h1(append(append([]byte{}, prefix...), id...))
+	}
+	return factors, nil
+}
+
+// encodeCommitments implements encode_group_commitment_list of section 4.3
+// of RFC 9591.
+func encodeCommitments(commitments []NonceCommitment) ([]byte, error) {
+	var out []byte
+	for _, c := range commitments {
+		for _, m := range []interface{ MarshalBinary() ([]byte, error) }{identifier(c.Identifier), c.Hiding, c.Binding} {
+			buf, err := m.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, buf...)
+		}
+	}
+	return out, nil
+}
+
+// groupCommitment implements compute_group_commitment of section 4.5 of RFC
+// 9591.
+func groupCommitment(commitments []NonceCommitment, factors map[int]kyber.Scalar) kyber.Point {
+	R := group.Point().Null()
+	for _, c := range commitments {
+		R.Add(R, c.Hiding)
+		R.Add(R, group.Point().Mul(factors[c.Identifier], c.Binding))
+	}
+	return R
+}
+
+// interpolatingValue implements derive_interpolating_value of section 4.2 of
+// RFC 9591: the Lagrange coefficient at 0 of the signer id among the signers.
+func interpolatingValue(commitments []NonceCommitment, id int) kyber.Scalar {
+	num := group.Scalar().One()
+	den := group.Scalar().One()
+	xi := identifier(id)
+	for _, c := range commitments {
+		if c.Identifier == id {
+			continue
+		}
+		xj := identifier(c.Identifier)
+		num.Mul(num, xj)
+		den.Mul(den, group.Scalar().Sub(xj, xi))
+	}
+	return num.Div(num, den)
+}
+
+// challenge implements compute_challenge of section 4.6 of RFC 9591, which
+// is the Ed25519 challenge.
+func challenge(R, groupPublic kyber.Point, msg []byte) (kyber.Scalar, error) {
+	return schnorr.Challenge(group, groupPublic, R, msg)
+}
+
+func identifier(id int) kyber.Scalar {
+	return group.Scalar().SetInt64(int64(id))
+}
+
+// h1, h3, h4 and h5 are the hash functions of section 6.1 of RFC 9591.
+func h1(m []byte) kyber.Scalar { return group.Scalar().SetBytes(hash("rho", m)) }
+func h3(m []byte) kyber.Scalar { return group.Scalar().SetBytes(hash("nonce", m)) }
+func h4(m []byte) []byte       { return hash("msg", m) }
+func h5(m []byte) []byte       { return hash("com", m) }
+
+func hash(tag string, m []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(contextString))
+	h.Write([]byte(tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
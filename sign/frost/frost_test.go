@@ -0,0 +1,177 @@
+package frost
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/share"
+	"github.com/dedis/kyber/sign/eddsa"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func sign(t *testing.T, signers []*ParticipantShare, msg []byte) ([]NonceCommitment, []SigShare) {
+	commitments := make([]NonceCommitment, len(signers))
+	nonces := make([]Nonce, len(signers))
+	for i, s := range signers {
+		var err error
+		commitments[i], nonces[i], err = Round1(s)
+		require.Nil(t, err)
+	}
+	sigShares := make([]SigShare, len(signers))
+	for i, s := range signers {
+		var err error
+		sigShares[i], err = Round2(s, nonces[i], msg, commitments)
+		require.Nil(t, err)
+		require.Nil(t, VerifyShare(sigShares[i], s.Public, s.GroupPublic, msg, commitments))
+	}
+	return commitments, sigShares
+}
+
+func TestFROST(t *testing.T) {
+	shares, pub, err := Setup(suite, 3, 5)
+	require.Nil(t, err)
+	require.Len(t, shares, 5)
+	msg := []byte("Hello FROST")
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var signers []*ParticipantShare
+		for _, i := range subset {
+			signers = append(signers, shares[i])
+		}
+		commitments, sigShares := sign(t, signers, msg)
+		sig, err := Aggregate(sigShares, msg, pub, commitments)
+		require.Nil(t, err)
+		require.Nil(t, eddsa.Verify(pub, msg, sig))
+	}
+}
+
+func TestFROSTInvalid(t *testing.T) {
+	shares, pub, err := Setup(suite, 2, 3)
+	require.Nil(t, err)
+	msg := []byte("Hello FROST")
+
+	// fewer than t signers cannot sign
+	commitments, sigShares := sign(t, shares[:1], msg)
+	_, err = Aggregate(sigShares, msg, pub, commitments)
+	require.Error(t, err)
+
+	// a bad share is detected and blamed
+	commitments, sigShares = sign(t, shares[:2], msg)
+	sigShares[1].Z = suite.Scalar().Add(sigShares[1].Z, suite.Scalar().One())
+	_, err = Aggregate(sigShares, msg, pub, commitments)
+	require.Error(t, err)
+	require.Nil(t, VerifyShare(sigShares[0], shares[0].Public, pub, msg, commitments))
+	require.Error(t, VerifyShare(sigShares[1], shares[1].Public, pub, msg, commitments))
+
+	// a nonce that does not match the commitment
+	c, n, err := Round1(shares[0])
+	require.Nil(t, err)
+	c2, _, err := Round1(shares[1])
+	require.Nil(t, err)
+	_, err = Round2(shares[0], n, msg, []NonceCommitment{c2})
+	require.Equal(t, errCommitment, err)
+	_, err = Round2(shares[0], n, msg, []NonceCommitment{c, c})
+	require.Equal(t, errCommitment, err)
+	other, _, err := Round1(shares[0])
+	require.Nil(t, err)
+	_, err = Round2(shares[0], n, msg, []NonceCommitment{other, c2})
+	require.Equal(t, errCommitment, err)
+
+	// identity or missing hiding and binding commitments are rejected
+	commitments, sigShares = sign(t, shares[:2], msg)
+	for _, tamper := range []func(*NonceCommitment){
+		func(c *NonceCommitment) { c.Hiding = suite.Point().Null() },
+		func(c *NonceCommitment) { c.Binding = suite.Point().Null() },
+		func(c *NonceCommitment) { c.Hiding = nil },
+		func(c *NonceCommitment) { c.Binding = nil },
+	} {
+		bad := append([]NonceCommitment{}, commitments...)
+		tamper(&bad[1])
+		_, err = Round2(shares[0], n, msg, append([]NonceCommitment{c}, bad[1]))
+		require.Equal(t, errCommitment, err)
+		_, err = Aggregate(sigShares, msg, pub, bad)
+		require.Equal(t, errCommitment, err)
+		require.Equal(t, errCommitment, VerifyShare(sigShares[0], shares[0].Public, pub, msg, bad))
+	}
+
+	_, _, err = Setup(suite, 4, 3)
+	require.Equal(t, errThreshold, err)
+	_, _, err = Setup(edwards25519.NewBlakeSHA256Ristretto255(), 2, 3)
+	require.Equal(t, errSuite, err)
+}
+
+func scalar(t *testing.T, h string) kyber.Scalar {
+	b, err := hex.DecodeString(h)
+	require.Nil(t, err)
+	s := group.Scalar()
+	require.Nil(t, s.UnmarshalBinary(b))
+	return s
+}
+
+func encode(t *testing.T, m interface{ MarshalBinary() ([]byte, error) }) string {
+	b, err := m.MarshalBinary()
+	require.Nil(t, err)
+	return hex.EncodeToString(b)
+}
+
+// Appendix E.1 of RFC 9591, FROST(Ed25519, SHA-512): the key generation by
+// the dealer and the signature of "test" by participants 1 and 3.
+func TestRFC9591(t *testing.T) {
+	secret := scalar(t, "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304")
+	a1 := scalar(t, "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204")
+	shares := newShares(suite, share.CoefficientsToPriPoly(suite, []kyber.Scalar{secret, a1}), 3)
+	require.Equal(t, "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673", encode(t, shares[0].GroupPublic))
+	require.Equal(t, "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509", encode(t, shares[0].Secret))
+	require.Equal(t, "a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d", encode(t, shares[1].Secret))
+	require.Equal(t, "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02", encode(t, shares[2].Secret))
+
+	msg := []byte("test")
+	hr, _ := hex.DecodeString("0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec")
+	br, _ := hex.DecodeString("69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501")
+	n1, err := newNonce(shares[0], hr, br)
+	require.Nil(t, err)
+	require.Equal(t, "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407", encode(t, n1.Hiding))
+	require.Equal(t, "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301", encode(t, n1.Binding))
+
+	// participant 3 only enters through its commitments and signature share
+	point := func(h string) kyber.Point {
+		b, err := hex.DecodeString(h)
+		require.Nil(t, err)
+		P := group.Point()
+		require.Nil(t, P.UnmarshalBinary(b))
+		return P
+	}
+	commitments := []NonceCommitment{
+		{
+			Identifier: 1,
+			Hiding:     group.Point().Mul(n1.Hiding, nil),
+			Binding:    group.Point().Mul(n1.Binding, nil),
+		},
+		{
+			Identifier: 3,
+			Hiding:     point("cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91"),
+			Binding:    point("7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552"),
+		},
+	}
+	require.Equal(t, "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3", encode(t, commitments[0].Hiding))
+	require.Equal(t, "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932", encode(t, commitments[0].Binding))
+
+	factors, err := bindingFactors(shares[0].GroupPublic, commitments, msg)
+	require.Nil(t, err)
+	require.Equal(t, "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603", encode(t, factors[1]))
+	require.Equal(t, "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f", encode(t, factors[3]))
+
+	s1, err := Round2(shares[0], n1, msg, commitments)
+	require.Nil(t, err)
+	require.Equal(t, "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603", encode(t, s1.Z))
+	s3 := SigShare{Identifier: 3, Z: scalar(t, "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007")}
+	require.Nil(t, VerifyShare(s3, shares[2].Public, shares[0].GroupPublic, msg, commitments))
+
+	sig, err := Aggregate([]SigShare{s1, s3}, msg, shares[0].GroupPublic, commitments)
+	require.Nil(t, err)
+	require.Equal(t, "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe"+
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b", hex.EncodeToString(sig))
+}
@@ -0,0 +1,289 @@
+// Package musig2 implements the MuSig2 multi-signature scheme of Nick, Ruffing
+// and Seurin, in the variant of BIP-327 adapted to kyber groups.
+//
+// The n signers first aggregate their public keys into a combined key with
+// AggregateKey. To sign a message, each of them creates a Signer and runs
+// three rounds, broadcasting the output of each round to the others:
+//
+//   - Round1 draws two secret nonces and returns a commitment to their public
+//     counterparts;
+//   - Round2 takes the commitments of all the signers and reveals the public
+//     nonces;
+//   - Round3 checks the revealed nonces against the commitments and returns
+//     a partial signature.
+//
+// Aggregate sums the partial signatures into a regular Schnorr signature,
+// which schnorr.Verify checks against the combined key. The commitment round
+// is not required by MuSig2 itself, but it lets honest signers abort as soon
+// as a cosigner changes its nonces after seeing the others.
+package musig2
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/sign/schnorr"
+)
+
+// Suite represents the set of functionalities needed by the package musig2.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+// Nonces are the two public nonces revealed by a signer in Round2.
+type Nonces struct {
+	R1, R2 kyber.Point
+}
+
+// Signer is the state of one signer during a signing session. A Signer must
+// only be used for one message.
+type Signer struct {
+	suite    Suite
+	private  kyber.Scalar
+	publics  []kyber.Point
+	index    int
+	combined kyber.Point
+	coef     kyber.Scalar
+
+	r1, r2      kyber.Scalar
+	nonces      *Nonces
+	commitments [][]byte
+}
+
+// AggregateKey returns the combined public key of publics, the sum of the
+// keys Xi weighted by the coefficients ai = H(L || Xi), where L is a hash of
+// the whole list. The order of the list matters.
+func AggregateKey(suite Suite, publics []kyber.Point) (kyber.Point, error) {
+	if len(publics) == 0 {
+		return nil, errors.New("musig2: no public keys")
+	}
+	L, err := keyListHash(publics)
+	if err != nil {
+		return nil, err
+	}
+	combined := suite.Point().Null()
+	for _, X := range publics {
+		a, err := keyCoefficient(suite, L, X)
+		if err != nil {
+			return nil, err
+		}
+		combined.Add(combined, suite.Point().Mul(a, X))
+	}
+	return combined, nil
+}
+
+// NewSigner returns the signer owning private among the signers of publics.
+func NewSigner(suite Suite, private kyber.Scalar, publics []kyber.Point) (*Signer, error) {
+	public := suite.Point().Mul(private, nil)
+	index := -1
+	for i, X := range publics {
+		if X.Equal(public) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, errors.New("musig2: public key of the signer not in the list")
+	}
+	combined, err := AggregateKey(suite, publics)
+	if err != nil {
+		return nil, err
+	}
+	L, err := keyListHash(publics)
+	if err != nil {
+		return nil, err
+	}
+	coef, err := keyCoefficient(suite, L, public)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		suite:    suite,
+		private:  private,
+		publics:  publics,
+		index:    index,
+		combined: combined,
+		coef:     coef,
+	}, nil
+}
+
+// Index returns the position of the signer in the list of public keys.
+func (s *Signer) Index() int {
+	return s.index
+}
+
+// Round1 draws the secret nonces of the signer and returns the commitment to
+// be broadcast to the other signers.
+func (s *Signer) Round1() ([]byte, error) {
+	if s.nonces != nil {
+		return nil, errors.New("musig2: round 1 already done")
+	}
+	s.r1 = s.suite.Scalar().Pick(s.suite.RandomStream())
+	s.r2 = s.suite.Scalar().Pick(s.suite.RandomStream())
+	s.nonces = &Nonces{
+		R1: s.suite.Point().Mul(s.r1, nil),
+		R2: s.suite.Point().Mul(s.r2, nil),
+	}
+	return nonceCommitment(s.nonces)
+}
+
+// Round2 records the commitments of all the signers, in the order of the
+// public keys, and returns the public nonces of the signer.
+func (s *Signer) Round2(commitments [][]byte) (*Nonces, error) {
+	if s.nonces == nil {
+		return nil, errors.New("musig2: round 1 not done")
+	}
+	if len(commitments) != len(s.publics) {
+		return nil, errors.New("musig2: wrong number of commitments")
+	}
+	own, err := nonceCommitment(s.nonces)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(own, commitments[s.index]) {
+		return nil, errors.New("musig2: own commitment altered")
+	}
+	s.commitments = commitments
+	return s.nonces, nil
+}
+
+// Round3 checks the nonces of all the signers, in the order of the public
+// keys, against their commitments and returns the partial signature of msg.
+// The secret nonces are erased, so Round3 can only be called once.
+func (s *Signer) Round3(nonces []*Nonces, msg []byte) ([]byte, error) {
+	if s.commitments == nil || s.r1 == nil {
+		return nil, errors.New("musig2: round 2 not done")
+	}
+	if len(nonces) != len(s.publics) {
+		return nil, errors.New("musig2: wrong number of nonces")
+	}
+	for i, n := range nonces {
+		c, err := nonceCommitment(n)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(c, s.commitments[i]) {
+			return nil, fmt.Errorf("musig2: nonces of signer %d do not match its commitment", i)
+		}
+	}
+
+	R, b, err := aggregateNonces(s.suite, s.combined, nonces, msg)
+	if err != nil {
+		return nil, err
+	}
+	c, err := schnorr.Challenge(s.suite, s.combined, R, msg)
+	if err != nil {
+		return nil, err
+	}
+	// si = r1 + b·r2 + c·ai·xi
+	si := s.suite.Scalar().Mul(c, s.coef)
+	si.Mul(si, s.private)
+	si.Add(si, s.r1)
+	si.Add(si, s.suite.Scalar().Mul(b, s.r2))
+	s.r1, s.r2 = nil, nil
+
+	var buf bytes.Buffer
+	if _, err := R.MarshalTo(&buf); err != nil {
+		return nil, err
+	}
+	if _, err := si.MarshalTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Aggregate sums the partial signatures of all the signers into a Schnorr
+// signature of msg, and checks it against the combined public key. An
+// invalid partial signature makes the aggregation fail.
+func Aggregate(suite Suite, partialSigs [][]byte, combinedPub kyber.Point, msg []byte) ([]byte, error) {
+	if len(partialSigs) == 0 {
+		return nil, errors.New("musig2: no partial signatures")
+	}
+	pointLen := suite.PointLen()
+	if len(partialSigs[0]) != pointLen+suite.ScalarLen() {
+		return nil, errors.New("musig2: invalid partial signature length")
+	}
+	R := partialSigs[0][:pointLen]
+	s := suite.Scalar().Zero()
+	for i, sig := range partialSigs {
+		if len(sig) != pointLen+suite.ScalarLen() || !bytes.Equal(sig[:pointLen], R) {
+			return nil, fmt.Errorf("musig2: partial signature %d for another nonce", i)
+		}
+		si := suite.Scalar()
+		if err := si.UnmarshalBinary(sig[pointLen:]); err != nil {
+			return nil, err
+		}
+		s.Add(s, si)
+	}
+	S, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	signature := append(append([]byte{}, R...), S...)
+	if err := schnorr.Verify(suite, combinedPub, msg, signature); err != nil {
+		return nil, errors.New("musig2: invalid aggregate signature")
+	}
+	return signature, nil
+}
+
+// aggregateNonces returns the final nonce R = R1 + b·R2, where Rj is the sum
+// of the j-th nonces of the signers and b = H(R1 || R2 || X || msg).
+func aggregateNonces(suite Suite, combined kyber.Point, nonces []*Nonces, msg []byte) (kyber.Point, kyber.Scalar, error) {
+	R1, R2 := suite.Point().Null(), suite.Point().Null()
+	for _, n := range nonces {
+		R1.Add(R1, n.R1)
+		R2.Add(R2, n.R2)
+	}
+	b, err := hashToScalar(suite, "MuSig/noncecoef", msg, R1, R2, combined)
+	if err != nil {
+		return nil, nil, err
+	}
+	return R1.Add(R1, suite.Point().Mul(b, R2)), b, nil
+}
+
+func keyListHash(publics []kyber.Point) ([]byte, error) {
+	h := sha512.New()
+	h.Write([]byte("KeyAgg list"))
+	for _, X := range publics {
+		if _, err := X.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+func keyCoefficient(suite Suite, L []byte, X kyber.Point) (kyber.Scalar, error) {
+	return hashToScalar(suite, "KeyAgg coefficient", L, X)
+}
+
+func nonceCommitment(n *Nonces) ([]byte, error) {
+	if n == nil || n.R1 == nil || n.R2 == nil {
+		return nil, errors.New("musig2: missing nonces")
+	}
+	h := sha512.New()
+	h.Write([]byte("MuSig/noncecommit"))
+	if _, err := n.R1.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	if _, err := n.R2.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// hashToScalar hashes the tag, the data and the points into a scalar.
+func hashToScalar(suite Suite, tag string, data []byte, points ...kyber.Point) (kyber.Scalar, error) {
+	h := sha512.New()
+	h.Write([]byte(tag))
+	h.Write(data)
+	for _, P := range points {
+		if _, err := P.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	return suite.Scalar().SetBytes(h.Sum(nil)), nil
+}
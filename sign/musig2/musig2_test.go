@@ -0,0 +1,117 @@
+package musig2
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/sign/eddsa"
+	"github.com/dedis/kyber/sign/schnorr"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func setup(t *testing.T, n int) ([]*Signer, kyber.Point) {
+	privates := make([]kyber.Scalar, n)
+	publics := make([]kyber.Point, n)
+	for i := range privates {
+		privates[i] = suite.Scalar().Pick(suite.RandomStream())
+		publics[i] = suite.Point().Mul(privates[i], nil)
+	}
+	signers := make([]*Signer, n)
+	for i := range signers {
+		var err error
+		signers[i], err = NewSigner(suite, privates[i], publics)
+		require.Nil(t, err)
+		require.Equal(t, i, signers[i].Index())
+	}
+	combined, err := AggregateKey(suite, publics)
+	require.Nil(t, err)
+	return signers, combined
+}
+
+// run executes the three rounds, applying tamper to the nonces revealed in
+// round 2.
+func run(t *testing.T, signers []*Signer, msg []byte, tamper func([]*Nonces)) ([][]byte, []error) {
+	commitments := make([][]byte, len(signers))
+	for i, s := range signers {
+		var err error
+		commitments[i], err = s.Round1()
+		require.Nil(t, err)
+	}
+	nonces := make([]*Nonces, len(signers))
+	for i, s := range signers {
+		var err error
+		nonces[i], err = s.Round2(commitments)
+		require.Nil(t, err)
+	}
+	if tamper != nil {
+		tamper(nonces)
+	}
+	partials := make([][]byte, len(signers))
+	errs := make([]error, len(signers))
+	for i, s := range signers {
+		partials[i], errs[i] = s.Round3(nonces, msg)
+	}
+	return partials, errs
+}
+
+func TestMuSig2(t *testing.T) {
+	msg := []byte("Hello MuSig2")
+	signers, combined := setup(t, 3)
+	partials, errs := run(t, signers, msg, nil)
+	for _, err := range errs {
+		require.Nil(t, err)
+	}
+	sig, err := Aggregate(suite, partials, combined, msg)
+	require.Nil(t, err)
+	require.Nil(t, schnorr.Verify(suite, combined, msg, sig))
+	require.Nil(t, eddsa.Verify(combined, msg, sig))
+	require.Error(t, schnorr.Verify(suite, combined, []byte("Hello MuSig3"), sig))
+
+	// all the partial signatures are needed
+	_, err = Aggregate(suite, partials[:2], combined, msg)
+	require.Error(t, err)
+
+	// nonces cannot be reused
+	_, err = signers[0].Round3(nil, msg)
+	require.Error(t, err)
+}
+
+func TestMuSig2CheatingRound1(t *testing.T) {
+	msg := []byte("Hello MuSig2")
+	signers, _ := setup(t, 3)
+
+	// signer 2 reveals other nonces than those it committed to
+	_, errs := run(t, signers, msg, func(nonces []*Nonces) {
+		nonces[2] = &Nonces{
+			R1: suite.Point().Pick(suite.RandomStream()),
+			R2: nonces[2].R2,
+		}
+	})
+	for _, err := range errs {
+		require.Error(t, err)
+	}
+
+	// an altered partial signature makes the aggregation fail
+	signers, combined := setup(t, 3)
+	partials, _ := run(t, signers, msg, nil)
+	partials[2][len(partials[2])-1] ^= 1
+	_, err := Aggregate(suite, partials, combined, msg)
+	require.Error(t, err)
+}
+
+func TestAggregateKey(t *testing.T) {
+	signers, combined := setup(t, 2)
+	publics := signers[0].publics
+	reversed := []kyber.Point{publics[1], publics[0]}
+	other, err := AggregateKey(suite, reversed)
+	require.Nil(t, err)
+	require.False(t, combined.Equal(other))
+
+	_, err = NewSigner(suite, suite.Scalar().One(), publics)
+	require.Error(t, err)
+	_, err = AggregateKey(suite, nil)
+	require.Error(t, err)
+}
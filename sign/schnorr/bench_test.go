@@ -0,0 +1,96 @@
+package schnorr
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/key"
+	"github.com/stretchr/testify/require"
+)
+
+// The parallel benchmarks reveal contention in the state shared between
+// goroutines, like the suite. Run them at several levels of parallelism with
+//
+//	go test -run XXX -bench Parallel -cpu 1,4,8 ./sign/schnorr/
+//
+// Besides the time and allocations per operation, they report the
+// throughput in ops/s and the ops/s per proc: the latter stays constant as
+// long as the throughput scales linearly with GOMAXPROCS. TestParallelScaling
+// checks this with the timing build tag.
+
+var benchMsg = []byte("Hello Schnorr")
+
+func BenchmarkSignParallel(b *testing.B) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := Sign(suite, kp.Private, benchMsg); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	reportThroughput(b)
+}
+
+func BenchmarkVerifyParallel(b *testing.B) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	sig, err := Sign(suite, kp.Private, benchMsg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := Verify(suite, kp.Public, benchMsg, sig); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	reportThroughput(b)
+}
+
+func reportThroughput(b *testing.B) {
+	throughput := float64(b.N) / b.Elapsed().Seconds()
+	b.ReportMetric(throughput, "ops/s")
+	b.ReportMetric(throughput/float64(runtime.GOMAXPROCS(0)), "ops/s/proc")
+}
+
+// allocBudget is the number of heap objects that each operation may
+// allocate. The points and scalars of the kyber API live on the heap, so
+// Sign and Verify cannot stay below one object per call, but they must not
+// allocate more than they do today.
+var allocBudget = map[string]float64{
+	"Sign":   31,
+	"Verify": 24,
+}
+
+// TestAllocs flags the operations that allocate more than one object on the
+// heap and fails if one of them exceeds its allocBudget.
+func TestAllocs(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	sig, err := Sign(suite, kp.Private, benchMsg)
+	require.Nil(t, err)
+	ops := map[string]func(){
+		"Sign":   func() { Sign(suite, kp.Private, benchMsg) },
+		"Verify": func() { Verify(suite, kp.Public, benchMsg, sig) },
+	}
+	for name, op := range ops {
+		allocs := testing.AllocsPerRun(100, op)
+		if allocs > 1 {
+			t.Logf("%s allocates %.0f objects per operation", name, allocs)
+		}
+		if allocs > allocBudget[name] {
+			t.Errorf("%s allocates %.0f objects per operation, more than its budget of %.0f",
+				name, allocs, allocBudget[name])
+		}
+	}
+}
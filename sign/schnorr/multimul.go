@@ -0,0 +1,99 @@
+package schnorr
+
+import (
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/encoding"
+)
+
+// multiScalarMul returns Σ scalars[i]·points[i] with the bucket method of
+// Pippenger. The scalars are cut into windows of c bits; for each window,
+// from the most significant one, the points are added to the bucket of their
+// digit and the buckets are summed with their weights by running sums. The
+// cost is about (n + 2^(c+1))·bits/c additions for n points, instead of
+// roughly bits doublings and bits/4 additions per point for n separate
+// multiplications.
+//
+// It is not constant time, and must only be used on public values.
+func multiScalarMul(g kyber.Group, scalars []kyber.Scalar, points []kyber.Point) (kyber.Point, error) {
+	// scalar encodings, in big-endian byte order
+	little := encoding.LittleEndianScalars(g)
+	digits := make([][]byte, len(scalars))
+	for i, s := range scalars {
+		b, err := s.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if little {
+			for l, r := 0, len(b)-1; l < r; l, r = l+1, r-1 {
+				b[l], b[r] = b[r], b[l]
+			}
+		}
+		digits[i] = b
+	}
+	bits := 8 * g.ScalarLen()
+
+	c := windowSize(len(points))
+	buckets := make([]kyber.Point, 1<<uint(c))
+	for j := range buckets {
+		buckets[j] = g.Point()
+	}
+	// add sets *dst to *dst + Q without passing the receiver of Add as one of
+	// its arguments, which some groups do not support
+	spare := g.Point()
+	add := func(dst *kyber.Point, Q kyber.Point) {
+		spare.Add(*dst, Q)
+		*dst, spare = spare, *dst
+	}
+	sum, acc := g.Point(), g.Point()
+	result := g.Point().Null()
+	for w := (bits + c - 1) / c; w > 0; w-- {
+		for j := 0; j < c; j++ {
+			add(&result, result)
+		}
+		for j := range buckets {
+			buckets[j].Null()
+		}
+		for i, P := range points {
+			if d := window(digits[i], (w-1)*c, c); d != 0 {
+				add(&buckets[d], P)
+			}
+		}
+		// Σ j·buckets[j] = Σ_k Σ_{j>=k} buckets[j]
+		sum.Null()
+		acc.Null()
+		for j := len(buckets) - 1; j > 0; j-- {
+			add(&sum, buckets[j])
+			add(&acc, sum)
+		}
+		add(&result, acc)
+	}
+	return result, nil
+}
+
+// windowSize returns the number of bits of the windows of multiScalarMul for
+// n points, about log2(n) - 2 which balances the n additions into the
+// buckets with the 2^(c+1) additions of the buckets.
+func windowSize(n int) int {
+	c := 1
+	for 1<<uint(c+3) <= n {
+		c++
+	}
+	if c > 16 {
+		c = 16
+	}
+	return c
+}
+
+// window returns the c bits of the big-endian encoding b starting at bit
+// offset, counted from the least significant bit.
+func window(b []byte, offset, c int) int {
+	d := 0
+	for j := c - 1; j >= 0; j-- {
+		d <<= 1
+		bit := offset + j
+		if byteIndex := len(b) - 1 - bit/8; byteIndex >= 0 {
+			d |= int(b[byteIndex]>>uint(bit%8)) & 1
+		}
+	}
+	return d
+}
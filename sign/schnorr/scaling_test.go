@@ -0,0 +1,43 @@
+// +build timing
+
+package schnorr
+
+import (
+	"runtime"
+	"testing"
+)
+
+// TestParallelScaling checks that the throughput of the parallel benchmarks
+// grows linearly, within 20%, with GOMAXPROCS up to 4. It measures wall-clock
+// time, so it only runs with the timing build tag:
+//
+//	go test -tags timing -run Scaling ./sign/schnorr/
+func TestParallelScaling(t *testing.T) {
+	if runtime.NumCPU() < 4 {
+		t.Skip("skipping scaling test on less than 4 CPUs")
+	}
+	benchmarks := []struct {
+		name string
+		fn   func(*testing.B)
+	}{
+		{"Sign", BenchmarkSignParallel},
+		{"Verify", BenchmarkVerifyParallel},
+	}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, bench := range benchmarks {
+		var single float64
+		for _, procs := range []int{1, 2, 4} {
+			runtime.GOMAXPROCS(procs)
+			res := testing.Benchmark(bench.fn)
+			// operations per second
+			throughput := float64(res.N) / res.T.Seconds()
+			if procs == 1 {
+				single = throughput
+			}
+			t.Logf("%s: GOMAXPROCS=%d, %.0f ops/s", bench.name, procs, throughput)
+			if speedup := throughput / single; speedup < 0.8*float64(procs) {
+				t.Errorf("%s: speedup of %.2f with GOMAXPROCS=%d", bench.name, speedup, procs)
+			}
+		}
+	}
+}
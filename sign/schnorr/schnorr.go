@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/ctcompare"
 )
 
 // Suite represents the set of functionalities needed by the package schnorr.
@@ -30,14 +31,50 @@ type Suite interface {
 // signature can be verified with VerifySchnorr. It's also a valid EdDSA
 // signature when using the edwards25519 Group.
 func Sign(s Suite, private kyber.Scalar, msg []byte) ([]byte, error) {
-	var g kyber.Group = s
-	// create random secret k and public point commitment R
-	k := g.Scalar().Pick(s.RandomStream())
+	// create random secret k
+	k := s.Scalar().Pick(s.RandomStream())
+	return sign(s, private, k, msg)
+}
+
+// DeterministicSuite represents the set of functionalities needed by
+// SignDeterministic.
+type DeterministicSuite interface {
+	kyber.Group
+	kyber.XOFFactory
+}
+
+// SignDeterministic creates a Schnorr signature like Sign, with the secret
+// nonce picked from the XOF of the suite seeded with the private key and the
+// message, in the manner of RFC 6979 and RFC 8032, so that it works on any
+// group.
+func SignDeterministic(s DeterministicSuite, private kyber.Scalar, msg []byte) ([]byte, error) {
+	x, err := private.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// the encoding of the private key has a fixed length, so the input of
+	// the XOF is unambiguous
+	xof := s.XOF(nonceDST)
+	if _, err := xof.Write(x); err != nil {
+		return nil, err
+	}
+	if _, err := xof.Write(msg); err != nil {
+		return nil, err
+	}
+	k := s.Scalar().Pick(xof)
+	return sign(s, private, k, msg)
+}
+
+// nonceDST is the domain separation tag of the nonces of SignDeterministic.
+var nonceDST = []byte("kyber-schnorr-nonce")
+
+func sign(g kyber.Group, private, k kyber.Scalar, msg []byte) ([]byte, error) {
+	// create the public point commitment R
 	R := g.Point().Mul(k, nil)
 
 	// create hash(public || R || message)
 	public := g.Point().Mul(private, nil)
-	h, err := hash(g, public, R, msg)
+	h, err := Challenge(g, public, R, msg)
 	if err != nil {
 		return nil, err
 	}
@@ -59,23 +96,18 @@ func Sign(s Suite, private kyber.Scalar, msg []byte) ([]byte, error) {
 
 // Verify verifies a given Schnorr signature. It returns nil iff the
 // given signature is valid.
+//
+// On edwards25519, whose cofactor is 8, the cofactored equation
+// [8][s]G = [8]R + [8][h]A is checked, as RFC 8032 allows: a signature that
+// is only off by a point of small order is accepted. This is what lets
+// BatchVerify agree with Verify on every signature.
 func Verify(g kyber.Group, public kyber.Point, msg, sig []byte) error {
-	R := g.Point()
-	s := g.Scalar()
-	pointSize := R.MarshalSize()
-	scalarSize := s.MarshalSize()
-	sigSize := scalarSize + pointSize
-	if len(sig) != sigSize {
-		return fmt.Errorf("schnorr: signature of invalid length %d instead of %d", len(sig), sigSize)
-	}
-	if err := R.UnmarshalBinary(sig[:pointSize]); err != nil {
-		return err
-	}
-	if err := s.UnmarshalBinary(sig[pointSize:]); err != nil {
+	R, s, err := decodeSignature(g, sig)
+	if err != nil {
 		return err
 	}
 	// recompute hash(public || R || msg)
-	h, err := hash(g, public, R, msg)
+	h, err := Challenge(g, public, R, msg)
 	if err != nil {
 		return err
 	}
@@ -86,14 +118,102 @@ func Verify(g kyber.Group, public kyber.Point, msg, sig []byte) error {
 	Ah := g.Point().Mul(h, public)
 	RAs := g.Point().Add(R, Ah)
 
-	if !S.Equal(RAs) {
+	// compare them up to a small-order component, as BatchVerify does
+	c := cofactor(g)
+	if !ctcompare.PointEqual(g.Point().Mul(c, S), g.Point().Mul(c, RAs)) {
 		return errors.New("schnorr: invalid signature")
 	}
 
 	return nil
 }
 
-func hash(g kyber.Group, public, r kyber.Point, msg []byte) (kyber.Scalar, error) {
+// BatchVerify verifies a batch of Schnorr signatures, where sigs[i] is the
+// signature of messages[i] under publics[i]. It returns nil iff all the
+// signatures are valid, except with negligible probability.
+//
+// Instead of checking each equation s·G = R + h·A separately, every equation
+// is multiplied by a random scalar z taken from the suite's random stream and
+// the sum is checked at once: (Σ z·s)·G = Σ z·R + Σ (z·h)·A. The right-hand
+// side minus the left-hand side is computed with a single multi-scalar
+// multiplication of the 2n+1 points: for 64 signatures on edwards25519, it
+// takes less than half the time of 64 calls to Verify. An empty batch is
+// valid. On edwards25519, the sum is multiplied by the cofactor before being
+// compared to the identity, so that a small-order component of R or A, which
+// the random scalars cancel with a non-negligible probability, never decides
+// the result: a batch is accepted iff Verify accepts each of its signatures.
+func BatchVerify(suite Suite, publics []kyber.Point, messages [][]byte, sigs [][]byte) error {
+	if len(publics) != len(messages) || len(publics) != len(sigs) {
+		return fmt.Errorf("schnorr: batch of %d public keys, %d messages and %d signatures", len(publics), len(messages), len(sigs))
+	}
+	var g kyber.Group = suite
+	rand := suite.RandomStream()
+
+	// Σ z·R + Σ (z·h)·A - (Σ z·s)·G
+	sum := g.Scalar().Zero()
+	scalars := make([]kyber.Scalar, 0, 2*len(sigs)+1)
+	points := make([]kyber.Point, 0, 2*len(sigs)+1)
+	for i := range sigs {
+		R, s, err := decodeSignature(g, sigs[i])
+		if err != nil {
+			return err
+		}
+		h, err := Challenge(g, publics[i], R, messages[i])
+		if err != nil {
+			return err
+		}
+
+		z := g.Scalar().Pick(rand)
+		sum.Add(sum, s.Mul(z, s))
+		scalars = append(scalars, z, h.Mul(z, h))
+		points = append(points, R, publics[i])
+	}
+	scalars = append(scalars, sum.Neg(sum))
+	points = append(points, g.Point().Base())
+
+	P, err := multiScalarMul(g, scalars, points)
+	if err != nil {
+		return err
+	}
+	if !g.Point().Mul(cofactor(g), P).Equal(g.Point().Null()) {
+		return errors.New("schnorr: invalid signature in batch")
+	}
+	return nil
+}
+
+// cofactor returns the cofactor of the group by which the verification
+// equations are multiplied: 8 for edwards25519, and 1 for the other groups,
+// which are expected to have a prime order.
+func cofactor(g kyber.Group) kyber.Scalar {
+	if g.String() == "Ed25519" {
+		return g.Scalar().SetInt64(8)
+	}
+	return g.Scalar().One()
+}
+
+// decodeSignature splits a signature into its commitment R and response s.
+func decodeSignature(g kyber.Group, sig []byte) (kyber.Point, kyber.Scalar, error) {
+	R := g.Point()
+	s := g.Scalar()
+	pointSize := R.MarshalSize()
+	scalarSize := s.MarshalSize()
+	sigSize := scalarSize + pointSize
+	if len(sig) != sigSize {
+		return nil, nil, fmt.Errorf("schnorr: signature of invalid length %d instead of %d", len(sig), sigSize)
+	}
+	if err := R.UnmarshalBinary(sig[:pointSize]); err != nil {
+		return nil, nil, err
+	}
+	if err := s.UnmarshalBinary(sig[pointSize:]); err != nil {
+		return nil, nil, err
+	}
+	return R, s, nil
+}
+
+// Challenge returns the challenge H(R || public || msg) of a Schnorr
+// signature of msg with commitment R, where H is SHA-512 reduced to a scalar
+// as in EdDSA. Protocols that produce signatures checked by Verify, like the
+// threshold and multi-signature schemes, must use it for their challenge.
+func Challenge(g kyber.Group, public, r kyber.Point, msg []byte) (kyber.Scalar, error) {
 	h := sha512.New()
 	if _, err := r.MarshalTo(h); err != nil {
 		return nil, err
@@ -6,9 +6,12 @@ import (
 	"testing"
 	"testing/quick"
 
+	"github.com/dedis/kyber"
 	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/pairing/bn256"
 	"github.com/dedis/kyber/sign/eddsa"
 	"github.com/dedis/kyber/util/key"
+	"github.com/dedis/kyber/util/random"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,6 +69,39 @@ func TestEdDSACompatibility(t *testing.T) {
 
 }
 
+func TestSignDeterministic(t *testing.T) {
+	msg := []byte("Hello Schnorr")
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+
+	s1, err := SignDeterministic(suite, kp.Private, msg)
+	assert.Nil(t, err)
+	assert.Nil(t, Verify(suite, kp.Public, msg, s1))
+	assert.Nil(t, eddsa.Verify(kp.Public, msg, s1))
+	s2, err := SignDeterministic(suite, kp.Private, msg)
+	assert.Nil(t, err)
+	assert.Equal(t, s1, s2)
+
+	s2, err = SignDeterministic(suite, kp.Private, []byte("Hello Schnorr!"))
+	assert.Nil(t, err)
+	assert.NotEqual(t, s1[:32], s2[:32])
+
+	ristretto := edwards25519.NewBlakeSHA256Ristretto255()
+	kp = key.NewKeyPair(ristretto)
+	s1, err = SignDeterministic(ristretto, kp.Private, msg)
+	assert.Nil(t, err)
+	assert.Nil(t, Verify(ristretto, kp.Public, msg, s1))
+
+	g1 := bn256.NewSuiteG1()
+	kp = key.NewKeyPair(g1)
+	s1, err = SignDeterministic(g1, kp.Private, msg)
+	assert.Nil(t, err)
+	assert.Nil(t, Verify(g1, kp.Public, msg, s1))
+	s2, err = SignDeterministic(g1, kp.Private, msg)
+	assert.Nil(t, err)
+	assert.Equal(t, s1, s2)
+}
+
 // Simple random stream using the random instance provided by the testing tool
 type quickstream struct {
 	rand *rand.Rand
@@ -96,3 +132,160 @@ func TestQuickSchnorrSignature(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestBatchVerify(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	n := 5
+	publics := make([]kyber.Point, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		kp := key.NewKeyPair(suite)
+		publics[i] = kp.Public
+		msgs[i] = []byte{byte(i)}
+		s, err := Sign(suite, kp.Private, msgs[i])
+		assert.Nil(t, err)
+		sigs[i] = s
+	}
+	assert.Nil(t, BatchVerify(suite, publics, msgs, sigs))
+	assert.Nil(t, BatchVerify(suite, nil, nil, nil))
+
+	// mismatched lengths
+	assert.Error(t, BatchVerify(suite, publics[1:], msgs, sigs))
+	assert.Error(t, BatchVerify(suite, publics, msgs, sigs[1:]))
+
+	// swapped messages
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	assert.Error(t, BatchVerify(suite, publics, msgs, sigs))
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+
+	// A commitment R = k·G + T, with T of order 2, gives a signature that
+	// only satisfies the cofactored equation. Verify and BatchVerify must
+	// agree on it, whatever the random scalars of the batch.
+	T := suite.Point()
+	assert.Nil(t, T.UnmarshalBinary(torsion2))
+	kp := key.NewKeyPair(suite)
+	k := suite.Scalar().Pick(suite.RandomStream())
+	R := suite.Point().Add(suite.Point().Mul(k, nil), T)
+	h, err := Challenge(suite, kp.Public, R, msgs[0])
+	assert.Nil(t, err)
+	sig, err := R.MarshalBinary()
+	assert.Nil(t, err)
+	sb, err := suite.Scalar().Add(k, suite.Scalar().Mul(h, kp.Private)).MarshalBinary()
+	assert.Nil(t, err)
+	sig = append(sig, sb...)
+	publics[0], sigs[0] = kp.Public, sig
+	assert.Nil(t, Verify(suite, kp.Public, msgs[0], sig))
+	for i := 0; i < 16; i++ {
+		assert.Nil(t, BatchVerify(suite, publics, msgs, sigs))
+	}
+
+	// adding T to the commitment of a valid signature changes the challenge
+	sig, err = Sign(suite, kp.Private, msgs[0])
+	assert.Nil(t, err)
+	R = suite.Point()
+	assert.Nil(t, R.UnmarshalBinary(sig[:32]))
+	rb, err := R.Add(R, T).MarshalBinary()
+	assert.Nil(t, err)
+	sigs[0] = append(rb, sig[32:]...)
+	assert.Error(t, Verify(suite, kp.Public, msgs[0], sigs[0]))
+	for i := 0; i < 16; i++ {
+		assert.Error(t, BatchVerify(suite, publics, msgs, sigs))
+	}
+}
+
+// torsion2 encodes the point (0, -1) of edwards25519, of order 2.
+var torsion2 = []byte{
+	0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+}
+
+func TestQuickBatchVerify(t *testing.T) {
+	// A batch with a single corrupted signature must always be rejected.
+	f := func(rand *quickstream, msg []byte, idx, pos uint8, flip byte) bool {
+		suite := edwards25519.NewBlakeSHA256Ed25519WithRand(rand)
+		n := 4
+		publics := make([]kyber.Point, n)
+		msgs := make([][]byte, n)
+		sigs := make([][]byte, n)
+		for i := 0; i < n; i++ {
+			kp := key.NewKeyPair(suite)
+			publics[i] = kp.Public
+			msgs[i] = append([]byte{byte(i)}, msg...)
+			s, err := Sign(suite, kp.Private, msgs[i])
+			if err != nil {
+				return false
+			}
+			sigs[i] = s
+		}
+		if BatchVerify(suite, publics, msgs, sigs) != nil {
+			return false
+		}
+
+		if flip == 0 {
+			flip = 1
+		}
+		bad := sigs[int(idx)%n]
+		bad[int(pos)%len(bad)] ^= flip
+		return BatchVerify(suite, publics, msgs, sigs) != nil
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultiScalarMul(t *testing.T) {
+	groups := []kyber.Group{
+		edwards25519.NewBlakeSHA256Ed25519(),
+		bn256.NewSuiteG1(),
+	}
+	for _, g := range groups {
+		for _, n := range []int{0, 1, 2, 5, 33} {
+			scalars := make([]kyber.Scalar, n)
+			points := make([]kyber.Point, n)
+			expected := g.Point().Null()
+			for i := range points {
+				scalars[i] = g.Scalar().Pick(random.New())
+				points[i] = g.Point().Pick(random.New())
+				expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+			}
+			P, err := multiScalarMul(g, scalars, points)
+			assert.Nil(t, err)
+			assert.True(t, P.Equal(expected), "%s: %d points", g, n)
+		}
+	}
+}
+
+func benchmarkBatch(b *testing.B, batch bool) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	n := 64
+	publics := make([]kyber.Point, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		kp := key.NewKeyPair(suite)
+		publics[i] = kp.Public
+		msgs[i] = []byte{byte(i)}
+		sigs[i], _ = Sign(suite, kp.Private, msgs[i])
+	}
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		if batch {
+			if err := BatchVerify(suite, publics, msgs, sigs); err != nil {
+				b.Fatal(err)
+			}
+			continue
+		}
+		for i := range sigs {
+			if err := Verify(suite, publics[i], msgs[i], sigs[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkBatchVerify64(b *testing.B) { benchmarkBatch(b, true) }
+func BenchmarkVerify64(b *testing.B)      { benchmarkBatch(b, false) }
@@ -0,0 +1,96 @@
+// Package zip215 implements the Ed25519 signature validation rules of ZIP
+// 215, which Zcash uses so that all validators agree on the validity of every
+// signature, including the edge cases on which Ed25519 implementations differ.
+//
+// Signatures are encoded as R || S, where R is a compressed point and S a
+// little-endian scalar. The rules are the following:
+//
+//   - S must be a canonical encoding, that is, less than the group order;
+//   - A and R may use non-canonical encodings, of y coordinates reduced
+//     modulo p and of a negative zero x coordinate, and may have small
+//     order;
+//   - the cofactored equation [8][S]B = [8]R + [8][k]A is checked, with k
+//     hashed over the encodings of R and A as given.
+//
+// Contrary to what one may expect, small-order points and non-canonical
+// point encodings are therefore accepted and not rejected.
+package zip215
+
+import (
+	"crypto/sha512"
+	"errors"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/sign/schnorr"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// order is the little-endian encoding of the order of the base point,
+// 2^252 + 27742317777372353535851937790883648493.
+var order = [32]byte{
+	0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+	0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+}
+
+var errInvalid = errors.New("zip215: invalid signature")
+
+// Sign returns the Ed25519 signature R || S of msg with the private scalar,
+// using a random nonce. It is the signature of schnorr.Sign on edwards25519.
+func Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	return schnorr.Sign(suite, private, msg)
+}
+
+// Verify checks the signature sig of msg under the encoded public key with
+// the rules of ZIP 215.
+func Verify(public, msg, sig []byte) error {
+	if len(public) != 32 || len(sig) != 64 {
+		return errInvalid
+	}
+	A := suite.Point()
+	if err := A.UnmarshalBinary(public); err != nil {
+		return errInvalid
+	}
+	R := suite.Point()
+	if err := R.UnmarshalBinary(sig[:32]); err != nil {
+		return errInvalid
+	}
+	if !canonicalScalar(sig[32:]) {
+		return errInvalid
+	}
+	S := suite.Scalar()
+	if err := S.UnmarshalBinary(sig[32:]); err != nil {
+		return errInvalid
+	}
+
+	// k = H(R || A || msg), over the encodings as given
+	h := sha512.New()
+	h.Write(sig[:32])
+	h.Write(public)
+	h.Write(msg)
+	k := suite.Scalar().SetBytes(h.Sum(nil))
+
+	// [8]([S]B - R - [k]A) must be the identity
+	P := suite.Point().Mul(S, nil)
+	P.Sub(P, R)
+	P.Sub(P, suite.Point().Mul(k, A))
+	P.Mul(suite.Scalar().SetInt64(8), P)
+	if !P.Equal(suite.Point().Null()) {
+		return errInvalid
+	}
+	return nil
+}
+
+// canonicalScalar tells whether the little-endian scalar s is less than the
+// group order.
+func canonicalScalar(s []byte) bool {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] != order[i] {
+			return s[i] < order[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package zip215
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	private := suite.Scalar().Pick(suite.RandomStream())
+	public, err := suite.Point().Mul(private, nil).MarshalBinary()
+	require.Nil(t, err)
+	msg := []byte("Hello ZIP 215")
+	sig, err := Sign(private, msg)
+	require.Nil(t, err)
+	require.Nil(t, Verify(public, msg, sig))
+	require.True(t, ed25519.Verify(public, msg, sig))
+
+	require.Equal(t, errInvalid, Verify(public, []byte("another message"), sig))
+	require.Equal(t, errInvalid, Verify(public, msg, sig[:63]))
+
+	// S + order is the same scalar, but a non-canonical encoding
+	S := new(big.Int).SetBytes(reverse(sig[32:]))
+	S.Add(S, new(big.Int).SetBytes(reverse(order[:])))
+	altered := append(append([]byte{}, sig[:32]...), reverse(S.FillBytes(make([]byte, 32)))...)
+	require.Equal(t, errInvalid, Verify(public, msg, altered))
+}
+
+// TestSmallOrder checks the test vectors of ZIP 215: the signatures with S = 0
+// whose A and R are any of the 14 encodings of small-order points, of which 6
+// are non-canonical, are all valid.
+func TestSmallOrder(t *testing.T) {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	y8, _ := new(big.Int).SetString("7a03ac9277fdc74ec6cc392cfa53202a0f67100d760b3cba4fd84d3d706a17c7", 16)
+	ys := []*big.Int{
+		big.NewInt(1),                      // identity
+		new(big.Int).Sub(p, big.NewInt(1)), // order 2
+		big.NewInt(0),                      // order 4
+		y8,                                 // order 8
+		new(big.Int).Sub(p, y8),            // order 8
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), 255)
+	var encodings [][]byte
+	for _, y := range ys {
+		for _, v := range []*big.Int{y, new(big.Int).Add(y, p)} {
+			if v.Cmp(limit) >= 0 {
+				continue
+			}
+			for _, sign := range []byte{0, 0x80} {
+				b := reverse(v.FillBytes(make([]byte, 32)))
+				b[31] |= sign
+				P := suite.Point()
+				if P.UnmarshalBinary(b) != nil {
+					continue
+				}
+				P8 := suite.Point().Mul(suite.Scalar().SetInt64(8), P)
+				require.True(t, P8.Equal(suite.Point().Null()), hex.EncodeToString(b))
+				encodings = append(encodings, b)
+			}
+		}
+	}
+	require.Len(t, encodings, 14)
+
+	msg := []byte("Zcash")
+	for _, A := range encodings {
+		for _, R := range encodings {
+			sig := append(append([]byte{}, R...), make([]byte, 32)...)
+			require.Nil(t, Verify(A, msg, sig), "A %x R %x", A, R)
+		}
+	}
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,166 @@
+// Package timestamper implements a signed timestamp service, for instance to
+// order the entries of a distributed log.
+//
+// A Timestamper signs the hash of a message together with the current time
+// using Schnorr signatures. The signed data is the DER encoding of
+//
+//	SignedData ::= SEQUENCE {
+//	    time        GeneralizedTime,
+//	    messageHash OCTET STRING,
+//	    previous    OCTET STRING
+//	}
+//
+// so that the timestamps can be verified independently of this package. The
+// time has a precision of one second. A TimestampChain links consecutive
+// timestamps by setting previous to the signature of the timestamp before;
+// previous is empty for the first timestamp of a chain and for timestamps
+// outside of a chain.
+package timestamper
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/sign/schnorr"
+)
+
+// Suite represents the set of functionalities needed by the package
+// timestamper.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+// SignedTimestamp is the signature of a message hash at a given time.
+type SignedTimestamp struct {
+	Time        time.Time
+	MessageHash []byte
+	// Previous is the signature of the previous timestamp of a chain.
+	Previous []byte
+	Sig      []byte
+}
+
+type signedData struct {
+	Time        time.Time `asn1:"generalized"`
+	MessageHash []byte
+	Previous    []byte
+}
+
+// Encode returns the DER encoding of the data signed by the timestamp.
+func (ts *SignedTimestamp) Encode() ([]byte, error) {
+	return asn1.Marshal(signedData{ts.Time.UTC(), ts.MessageHash, ts.Previous})
+}
+
+// Timestamper signs timestamps with a private key.
+type Timestamper struct {
+	suite   Suite
+	private kyber.Scalar
+	now     func() time.Time
+}
+
+// NewTimestamper returns a Timestamper signing with the private key.
+func NewTimestamper(suite Suite, private kyber.Scalar) *Timestamper {
+	return &Timestamper{suite: suite, private: private, now: time.Now}
+}
+
+// Timestamp returns the signed timestamp of messageHash at the current time.
+func (t *Timestamper) Timestamp(messageHash []byte) (*SignedTimestamp, error) {
+	return t.timestamp(messageHash, nil)
+}
+
+func (t *Timestamper) timestamp(messageHash, previous []byte) (*SignedTimestamp, error) {
+	if len(messageHash) == 0 {
+		return nil, errors.New("timestamper: empty message hash")
+	}
+	ts := &SignedTimestamp{
+		Time:        t.now().UTC().Truncate(time.Second),
+		MessageHash: append([]byte{}, messageHash...),
+		Previous:    append([]byte{}, previous...),
+	}
+	msg, err := ts.Encode()
+	if err != nil {
+		return nil, err
+	}
+	if ts.Sig, err = schnorr.Sign(t.suite, t.private, msg); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// Verify checks the signature of the timestamp against the public key.
+func Verify(suite Suite, pub kyber.Point, ts *SignedTimestamp) error {
+	if ts == nil {
+		return errors.New("timestamper: no timestamp")
+	}
+	msg, err := ts.Encode()
+	if err != nil {
+		return err
+	}
+	return schnorr.Verify(suite, pub, msg, ts.Sig)
+}
+
+// TimestampChain issues timestamps, each linked to the previous one. It can
+// be used from multiple goroutines.
+type TimestampChain struct {
+	sync.Mutex
+	t     *Timestamper
+	chain []*SignedTimestamp
+}
+
+// NewTimestampChain returns an empty chain of timestamps issued by t.
+func NewTimestampChain(t *Timestamper) *TimestampChain {
+	return &TimestampChain{t: t}
+}
+
+// Append adds the timestamp of messageHash to the chain and returns it.
+func (c *TimestampChain) Append(messageHash []byte) (*SignedTimestamp, error) {
+	c.Lock()
+	defer c.Unlock()
+	var previous []byte
+	if len(c.chain) > 0 {
+		previous = c.chain[len(c.chain)-1].Sig
+	}
+	ts, err := c.t.timestamp(messageHash, previous)
+	if err != nil {
+		return nil, err
+	}
+	c.chain = append(c.chain, ts)
+	return ts, nil
+}
+
+// Timestamps returns the timestamps of the chain, in order.
+func (c *TimestampChain) Timestamps() []*SignedTimestamp {
+	c.Lock()
+	defer c.Unlock()
+	return append([]*SignedTimestamp{}, c.chain...)
+}
+
+// VerifyChain checks that the timestamps are validly signed, that each one
+// is linked to the one before it, and that their times never decrease.
+func VerifyChain(suite Suite, pub kyber.Point, chain []*SignedTimestamp) error {
+	var prev *SignedTimestamp
+	for i, ts := range chain {
+		if err := Verify(suite, pub, ts); err != nil {
+			return fmt.Errorf("timestamper: timestamp %d: %v", i, err)
+		}
+		if prev == nil {
+			if len(ts.Previous) != 0 {
+				return errors.New("timestamper: first timestamp linked to another")
+			}
+		} else {
+			if !bytes.Equal(ts.Previous, prev.Sig) {
+				return fmt.Errorf("timestamper: timestamp %d not linked to the previous one", i)
+			}
+			if ts.Time.Before(prev.Time) {
+				return fmt.Errorf("timestamper: timestamp %d older than the previous one", i)
+			}
+		}
+		prev = ts
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package timestamper
+
+import (
+	"crypto/sha256"
+	"encoding/asn1"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/key"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func newTimestamper() (*Timestamper, *key.Pair, *time.Time) {
+	kp := key.NewKeyPair(suite)
+	t := NewTimestamper(suite, kp.Private)
+	now := time.Date(2018, 3, 1, 12, 0, 0, 500, time.UTC)
+	t.now = func() time.Time { return now }
+	return t, kp, &now
+}
+
+func TestTimestamp(t *testing.T) {
+	ts, kp, now := newTimestamper()
+	h := sha256.Sum256([]byte("log entry"))
+	stamp, err := ts.Timestamp(h[:])
+	require.Nil(t, err)
+	require.Equal(t, now.Truncate(time.Second), stamp.Time)
+	require.Nil(t, Verify(suite, kp.Public, stamp))
+
+	// the signed data is plain DER
+	msg, err := stamp.Encode()
+	require.Nil(t, err)
+	var decoded signedData
+	rest, err := asn1.Unmarshal(msg, &decoded)
+	require.Nil(t, err)
+	require.Empty(t, rest)
+	require.Equal(t, h[:], decoded.MessageHash)
+	require.True(t, stamp.Time.Equal(decoded.Time))
+
+	other := *stamp
+	other.Time = other.Time.Add(time.Second)
+	require.Error(t, Verify(suite, kp.Public, &other))
+	other = *stamp
+	other.MessageHash = []byte("another hash")
+	require.Error(t, Verify(suite, kp.Public, &other))
+	require.Error(t, Verify(suite, suite.Point().Pick(suite.RandomStream()), stamp))
+
+	_, err = ts.Timestamp(nil)
+	require.Error(t, err)
+}
+
+func TestTimestampChain(t *testing.T) {
+	ts, kp, now := newTimestamper()
+	chain := NewTimestampChain(ts)
+	for _, entry := range []string{"one", "two", "three", "four"} {
+		h := sha256.Sum256([]byte(entry))
+		_, err := chain.Append(h[:])
+		require.Nil(t, err)
+		*now = now.Add(time.Minute)
+	}
+	stamps := chain.Timestamps()
+	require.Len(t, stamps, 4)
+	require.Empty(t, stamps[0].Previous)
+	require.Equal(t, stamps[0].Sig, stamps[1].Previous)
+	require.Nil(t, VerifyChain(suite, kp.Public, stamps))
+
+	// dropping, reordering or re-signing an entry breaks the chain
+	require.Error(t, VerifyChain(suite, kp.Public, append(stamps[:1:1], stamps[2:]...)))
+	require.Error(t, VerifyChain(suite, kp.Public, stamps[1:]))
+	swapped := []*SignedTimestamp{stamps[0], stamps[2], stamps[1], stamps[3]}
+	require.Error(t, VerifyChain(suite, kp.Public, swapped))
+
+	h := sha256.Sum256([]byte("forged"))
+	forged, err := ts.timestamp(h[:], stamps[1].Sig)
+	require.Nil(t, err)
+	require.Error(t, VerifyChain(suite, kp.Public, []*SignedTimestamp{stamps[0], stamps[1], forged, stamps[3]}))
+
+	// a timestamp going back in time is rejected
+	*now = now.Add(-time.Hour)
+	h = sha256.Sum256([]byte("five"))
+	_, err = chain.Append(h[:])
+	require.Nil(t, err)
+	require.Error(t, VerifyChain(suite, kp.Public, chain.Timestamps()))
+}
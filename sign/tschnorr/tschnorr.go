@@ -0,0 +1,123 @@
+// Package tschnorr implements a (t,n)-threshold Schnorr signature scheme based
+// on Shamir secret sharing.
+//
+// During setup, DealerSetup acts as a trusted dealer: it picks a random
+// secret, splits it in n shares with a polynomial of degree t-1, and returns
+// the key share of every participant with the public polynomial, whose
+// constant term is the joint public key X. The dealer knows the secret, so it
+// must be trusted and forget it. Without a trusted dealer, the participants
+// can instead run a distributed key generation, like share/dkg/pedersen,
+// where each of them only learns its own share, and build their KeyShare
+// from its output:
+//
+//	key := &KeyShare{Share: dks.Share, Public: share.NewPubPoly(suite, nil, dks.Commits)}
+//
+// To sign a message m, the participants first share a one-time random nonce
+// k with public commitment R, again with DealerSetup or a distributed key
+// generation. Each signer then issues a partial signature
+// si = ki + H(R || X || m)·xi with SignShare, and any t of them can be
+// combined into a regular Schnorr signature R || s with CombineShares. The
+// result can be checked against X with Verify, or with the schnorr package
+// directly.
+package tschnorr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/share"
+	"github.com/dedis/kyber/sign/schnorr"
+)
+
+// Suite represents the set of functionalities needed by the package tschnorr.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+// KeyShare is the share of one participant in a shared secret, along with
+// the commitments to the sharing polynomial.
+type KeyShare struct {
+	Share  *share.PriShare
+	Public *share.PubPoly
+}
+
+// DealerSetup shares a random secret among n participants with threshold t,
+// as a trusted dealer. It returns the key share of each participant, indexed
+// from 0 to n-1, and the public polynomial whose constant term is the public
+// key of the secret. A fresh setup must be run for every signing nonce.
+func DealerSetup(suite Suite, t, n int) ([]*KeyShare, *share.PubPoly, error) {
+	if t < 1 || t > n {
+		return nil, nil, fmt.Errorf("tschnorr: invalid threshold %d for %d participants", t, n)
+	}
+	poly := share.NewPriPoly(suite, t, nil, suite.RandomStream())
+	public := poly.Commit(nil)
+	keys := make([]*KeyShare, n)
+	for i, s := range poly.Shares(n) {
+		keys[i] = &KeyShare{Share: s, Public: public}
+	}
+	return keys, public, nil
+}
+
+// SignShare creates the partial signature si = ki + H(R || X || msg)·xi of
+// msg, where xi is the key share and ki the nonce share of the signer. Both
+// shares must belong to the same participant, and the nonce must never be
+// used for more than one message.
+func SignShare(suite Suite, key, nonce *KeyShare, msg []byte) (*share.PriShare, error) {
+	if key.Share.I != nonce.Share.I {
+		return nil, errors.New("tschnorr: key and nonce shares of different participants")
+	}
+	h, err := schnorr.Challenge(suite, key.Public.Commit(), nonce.Public.Commit(), msg)
+	if err != nil {
+		return nil, err
+	}
+	s := suite.Scalar().Mul(h, key.Share.V)
+	s.Add(s, nonce.Share.V)
+	return &share.PriShare{I: key.Share.I, V: s}, nil
+}
+
+// CombineShares checks the given partial signatures against the public key
+// and nonce polynomials, and combines t of them into a Schnorr signature
+// R || s of msg under the joint public key. It returns an error if a partial
+// signature is invalid or if there are fewer than t of them.
+func CombineShares(suite Suite, public, nonce *share.PubPoly, msg []byte, partials []*share.PriShare, t, n int) ([]byte, error) {
+	h, err := schnorr.Challenge(suite, public.Commit(), nonce.Commit(), msg)
+	if err != nil {
+		return nil, err
+	}
+	// si·G must equal Ri + h·Xi
+	left := suite.Point()
+	right := suite.Point()
+	for _, p := range partials {
+		if p == nil {
+			continue
+		}
+		left.Mul(p.V, nil)
+		right.Mul(h, public.Eval(p.I).V)
+		right.Add(right, nonce.Eval(p.I).V)
+		if !left.Equal(right) {
+			return nil, fmt.Errorf("tschnorr: invalid partial signature from participant %d", p.I)
+		}
+	}
+
+	s, err := share.RecoverSecret(suite, partials, t, n)
+	if err != nil {
+		return nil, err
+	}
+	R, err := nonce.Commit().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	S, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(R, S...), nil
+}
+
+// Verify checks a combined signature of msg under the joint public key. It is
+// the regular Schnorr verification.
+func Verify(suite Suite, public kyber.Point, msg, sig []byte) error {
+	return schnorr.Verify(suite, public, msg, sig)
+}
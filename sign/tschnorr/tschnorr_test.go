@@ -0,0 +1,84 @@
+package tschnorr
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/share"
+	"github.com/dedis/kyber/sign/eddsa"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func partialSigs(t *testing.T, keys, nonces []*KeyShare, msg []byte) []*share.PriShare {
+	partials := make([]*share.PriShare, len(keys))
+	for i := range keys {
+		ps, err := SignShare(suite, keys[i], nonces[i], msg)
+		require.Nil(t, err)
+		partials[i] = ps
+	}
+	return partials
+}
+
+func TestTSchnorr(t *testing.T) {
+	n, thr := 5, 3
+	msg := []byte("Hello threshold Schnorr")
+
+	keys, public, err := DealerSetup(suite, thr, n)
+	require.Nil(t, err)
+	nonces, noncePoly, err := DealerSetup(suite, thr, n)
+	require.Nil(t, err)
+	partials := partialSigs(t, keys, nonces, msg)
+
+	// any t of the n partial signatures are enough
+	for _, subset := range [][]*share.PriShare{partials[:thr], partials[n-thr:], {partials[0], partials[2], partials[4]}} {
+		sig, err := CombineShares(suite, public, noncePoly, msg, subset, thr, n)
+		require.Nil(t, err)
+		require.Nil(t, Verify(suite, public.Commit(), msg, sig))
+		require.Nil(t, eddsa.Verify(public.Commit(), msg, sig))
+		require.Error(t, Verify(suite, public.Commit(), []byte("another message"), sig))
+	}
+}
+
+func TestTSchnorrNotEnoughShares(t *testing.T) {
+	n, thr := 5, 3
+	msg := []byte("Hello threshold Schnorr")
+
+	keys, public, err := DealerSetup(suite, thr, n)
+	require.Nil(t, err)
+	nonces, noncePoly, err := DealerSetup(suite, thr, n)
+	require.Nil(t, err)
+	partials := partialSigs(t, keys, nonces, msg)
+
+	_, err = CombineShares(suite, public, noncePoly, msg, partials[:thr-1], thr, n)
+	require.Error(t, err)
+
+	// interpolating t-1 shares anyway does not give a valid signature
+	s, err := share.RecoverSecret(suite, partials[:thr-1], thr-1, n)
+	require.Nil(t, err)
+	R, _ := noncePoly.Commit().MarshalBinary()
+	S, _ := s.MarshalBinary()
+	require.Error(t, Verify(suite, public.Commit(), msg, append(R, S...)))
+}
+
+func TestTSchnorrInvalidPartial(t *testing.T) {
+	n, thr := 5, 3
+	msg := []byte("Hello threshold Schnorr")
+
+	keys, public, err := DealerSetup(suite, thr, n)
+	require.Nil(t, err)
+	nonces, noncePoly, err := DealerSetup(suite, thr, n)
+	require.Nil(t, err)
+	partials := partialSigs(t, keys, nonces, msg)
+
+	partials[1].V.Add(partials[1].V, suite.Scalar().One())
+	_, err = CombineShares(suite, public, noncePoly, msg, partials, thr, n)
+	require.Error(t, err)
+
+	_, err = SignShare(suite, keys[0], nonces[1], msg)
+	require.Error(t, err)
+
+	_, _, err = DealerSetup(suite, n+1, n)
+	require.Error(t, err)
+}
@@ -6,4 +6,5 @@ import (
 
 func init() {
 	register(edwards25519.NewBlakeSHA256Ed25519())
+	register(edwards25519.NewBlakeSHA256Ristretto255())
 }
@@ -5,15 +5,21 @@ package suites
 import (
 	"github.com/dedis/kyber/group/curve25519"
 	"github.com/dedis/kyber/group/nist"
+	"github.com/dedis/kyber/pairing/bls12381"
 	"github.com/dedis/kyber/pairing/bn256"
 )
 
 func init() {
 	register(curve25519.NewBlakeSHA256Curve25519(false))
 	register(curve25519.NewBlakeSHA256Curve25519(true))
+	register(curve25519.NewShakeSHA512Ed448())
 	register(nist.NewBlakeSHA256P256())
+	register(nist.NewBlakeSHA512P521())
 	register(nist.NewBlakeSHA256QR512())
 	register(bn256.NewSuiteG1())
 	register(bn256.NewSuiteG2())
 	register(bn256.NewSuiteGT())
+	register(bls12381.NewSuiteG1())
+	register(bls12381.NewSuiteG2())
+	register(bls12381.NewSuiteGT())
 }
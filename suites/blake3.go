@@ -0,0 +1,27 @@
+package suites
+
+import (
+	"hash"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/xof/blake3"
+)
+
+type blake3Suite struct {
+	Suite
+}
+
+// NewBlake3Suite returns a suite with the group of base, but where BLAKE3
+// replaces the hash function and the XOF. The outputs of what hashes with the
+// suite, such as cosi signatures, are thus not compatible with those of base.
+func NewBlake3Suite(base Suite) Suite {
+	return &blake3Suite{base}
+}
+
+func (s *blake3Suite) Hash() hash.Hash {
+	return blake3.NewHash()
+}
+
+func (s *blake3Suite) XOF(seed []byte) kyber.XOF {
+	return blake3.New(seed)
+}
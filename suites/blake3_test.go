@@ -0,0 +1,76 @@
+package suites
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/sign/cosi"
+	"github.com/stretchr/testify/require"
+)
+
+// schnorrSign produces a Schnorr signature of msg in the cosi format, which
+// hashes with the hash function of the suite.
+func schnorrSign(s Suite, private kyber.Scalar, public kyber.Point, msg []byte) ([]byte, error) {
+	v, V := cosi.Commit(s)
+	c, err := cosi.Challenge(s, V, public, msg)
+	if err != nil {
+		return nil, err
+	}
+	r, err := cosi.Response(s, private, v, c)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := cosi.NewMask(s, []kyber.Point{public}, public)
+	if err != nil {
+		return nil, err
+	}
+	return cosi.Sign(s, V, r, mask)
+}
+
+func schnorrVerify(s Suite, public kyber.Point, msg, sig []byte) error {
+	return cosi.Verify(s, []kyber.Point{public}, msg, sig, cosi.CompletePolicy{})
+}
+
+func TestBlake3Suite(t *testing.T) {
+	base := MustFind("Ed25519")
+	s := NewBlake3Suite(base)
+	require.Equal(t, base.String(), s.String())
+	require.Equal(t, 32, s.Hash().Size())
+
+	private := s.Scalar().Pick(s.RandomStream())
+	public := s.Point().Mul(private, nil)
+	msg := []byte("Hello BLAKE3")
+	sig, err := schnorrSign(s, private, public, msg)
+	require.Nil(t, err)
+	require.Nil(t, schnorrVerify(s, public, msg, sig))
+	require.Error(t, schnorrVerify(base, public, msg, sig))
+
+	sig, err = schnorrSign(base, private, public, msg)
+	require.Nil(t, err)
+	require.Error(t, schnorrVerify(s, public, msg, sig))
+}
+
+func benchmarkSignVerify(b *testing.B, s Suite) {
+	private := s.Scalar().Pick(s.RandomStream())
+	public := s.Point().Mul(private, nil)
+	msg := make([]byte, 4096)
+	b.SetBytes(int64(len(msg)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sig, err := schnorrSign(s, private, public, msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := schnorrVerify(s, public, msg, sig); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignVerifySHA256(b *testing.B) {
+	benchmarkSignVerify(b, MustFind("Ed25519"))
+}
+
+func BenchmarkSignVerifyBlake3(b *testing.B) {
+	benchmarkSignVerify(b, NewBlake3Suite(MustFind("Ed25519")))
+}
@@ -1,8 +1,8 @@
 // Package suites allows callers to look up Kyber suites by name.
 //
-// Currently, only the "ed25519" suite is available by default. To
-// have access to "curve25519" and the NIST suites (i.e. "P256"),
-// one needs to call the "go" tool with the tag "vartime", such as:
+// Currently, only the "ed25519" and "ristretto255" suites are available by
+// default. To have access to "curve25519" and the NIST suites (i.e. "P256"
+// and "P521"), one needs to call the "go" tool with the tag "vartime", such as:
 //
 //   go build -tags vartime
 //   go install -tags vartime
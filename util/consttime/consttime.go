@@ -0,0 +1,57 @@
+// Package consttime holds the timing tests of the scalar multiplication of
+// the groups, which check in the style of dudect that the running time does
+// not depend on the scalar, and the statistics they share with the timing
+// tests of other packages.
+//
+// The timing tests measure wall-clock time, so their outcome depends on the
+// load of the machine. They only run with the timing build tag:
+//
+//	go test -tags timing ./util/consttime/ ./util/ctcompare/
+//
+// The groups of package curve25519 are not tested: their Montgomery ladder
+// runs a fixed sequence of point operations, but on top of big.Int
+// arithmetic, whose time still depends on the values.
+package consttime
+
+import (
+	"math"
+	"sort"
+)
+
+// Threshold is the bound on the t statistic above which dudect reports a
+// leak with confidence.
+const Threshold = 10
+
+// Welch returns the t statistic of Welch's test for the means of x and y.
+func Welch(x, y []float64) float64 {
+	stats := func(v []float64) (float64, float64) {
+		mean := 0.0
+		for _, e := range v {
+			mean += e
+		}
+		mean /= float64(len(v))
+		variance := 0.0
+		for _, e := range v {
+			variance += (e - mean) * (e - mean)
+		}
+		return mean, variance / float64(len(v)-1)
+	}
+	mx, vx := stats(x)
+	my, vy := stats(y)
+	return (mx - my) / math.Sqrt(vx/float64(len(x))+vy/float64(len(y)))
+}
+
+// Crop removes the values above the 90th percentile, which are mostly caused
+// by scheduling and garbage collection.
+func Crop(v []float64) []float64 {
+	sorted := append([]float64{}, v...)
+	sort.Float64s(sorted)
+	limit := sorted[len(sorted)*9/10]
+	var out []float64
+	for _, e := range v {
+		if e <= limit {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+// +build timing
+
+package consttime
+
+import (
+	"math"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdwards25519(t *testing.T) {
+	testMul(t, edwards25519.NewBlakeSHA256Ed25519())
+}
+
+// testMul measures, in the style of dudect, the time of the multiplication
+// of a fixed point by a fixed scalar of low Hamming weight and by random
+// scalars, interleaving the two classes at random. A scalar multiplication
+// whose work depends on the bits of the scalar shows a large t statistic.
+func testMul(t *testing.T, g kyber.Group) {
+	A := g.Point().Pick(random.New())
+	low := g.Scalar().SetInt64(1 << 20)
+	stream := random.New()
+
+	const samples = 4000
+	var times [2][]float64
+	r := mrand.New(mrand.NewSource(1))
+	P := g.Point()
+	for i := 0; i < samples; i++ {
+		c := r.Intn(2)
+		s := low
+		if c == 1 {
+			s = g.Scalar().Pick(stream)
+		}
+		start := time.Now()
+		P.Mul(s, A)
+		times[c] = append(times[c], float64(time.Since(start)))
+	}
+	tstat := Welch(Crop(times[0]), Crop(times[1]))
+	t.Logf("%s: t statistic %f", g, tstat)
+	require.True(t, math.Abs(tstat) < Threshold, "%s: t statistic %f", g, tstat)
+}
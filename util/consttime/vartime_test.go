@@ -0,0 +1,14 @@
+// +build vartime,timing
+
+package consttime
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber/group/nist"
+)
+
+func TestNIST(t *testing.T) {
+	testMul(t, nist.NewBlakeSHA256P256())
+	testMul(t, nist.NewBlakeSHA512P521())
+}
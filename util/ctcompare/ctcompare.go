@@ -0,0 +1,37 @@
+// Package ctcompare compares scalars and points in constant time.
+//
+// The Equal methods of the kyber implementations make no promise about
+// their timing. ScalarEqual and PointEqual compare the canonical encodings
+// of their operands with crypto/subtle, so that the time they take does not
+// depend on where the operands differ.
+package ctcompare
+
+import (
+	"crypto/subtle"
+
+	"github.com/dedis/kyber"
+)
+
+// ScalarEqual returns whether a and b are equal, in time independent of
+// their values.
+func ScalarEqual(a, b kyber.Scalar) bool {
+	return equal(a, b)
+}
+
+// PointEqual returns whether a and b are equal, in time independent of
+// their values.
+func PointEqual(a, b kyber.Point) bool {
+	return equal(a, b)
+}
+
+func equal(a, b kyber.Marshaling) bool {
+	ab, err := a.MarshalBinary()
+	if err != nil {
+		return false
+	}
+	bb, err := b.MarshalBinary()
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(ab, bb) == 1
+}
@@ -0,0 +1,22 @@
+package ctcompare
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func TestEqual(t *testing.T) {
+	a := suite.Scalar().Pick(suite.RandomStream())
+	b := suite.Scalar().Pick(suite.RandomStream())
+	require.True(t, ScalarEqual(a, a.Clone()))
+	require.False(t, ScalarEqual(a, b))
+
+	A := suite.Point().Mul(a, nil)
+	B := suite.Point().Mul(b, nil)
+	require.True(t, PointEqual(A, suite.Point().Add(A, suite.Point().Null())))
+	require.False(t, PointEqual(A, B))
+}
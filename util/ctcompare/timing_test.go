@@ -0,0 +1,48 @@
+// +build timing
+
+package ctcompare
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/consttime"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScalarEqualTiming measures, in the style of dudect, the time of
+// ScalarEqual against an equal scalar and against scalars whose encodings
+// differ in every byte, interleaving the two classes at random. A leak of
+// the position of the first difference would show as a large t statistic.
+func TestScalarEqualTiming(t *testing.T) {
+	a := suite.Scalar().Pick(suite.RandomStream())
+	enc, err := a.MarshalBinary()
+	require.Nil(t, err)
+	// flip the low bit of every byte but the most significant one of the
+	// little-endian encoding, which keeps the scalar reduced
+	for i := range enc[:len(enc)-1] {
+		enc[i] ^= 0x01
+	}
+	far := suite.Scalar()
+	require.Nil(t, far.UnmarshalBinary(enc))
+	classes := []kyber.Scalar{a.Clone(), far}
+
+	const samples, batch = 5000, 20
+	var times [2][]float64
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < samples; i++ {
+		c := r.Intn(2)
+		start := time.Now()
+		for j := 0; j < batch; j++ {
+			ScalarEqual(a, classes[c])
+		}
+		times[c] = append(times[c], float64(time.Since(start)))
+	}
+	// drop the outliers caused by scheduling and garbage collection
+	tstat := consttime.Welch(consttime.Crop(times[0]), consttime.Crop(times[1]))
+	t.Logf("t statistic %f", tstat)
+	require.True(t, math.Abs(tstat) < consttime.Threshold, "t statistic %f", tstat)
+}
@@ -0,0 +1,225 @@
+// Package elligator encodes points of edwards25519 as strings of 32 bytes
+// that are indistinguishable from uniformly random ones, with the Elligator 2
+// map of section 6.7.1 of RFC 9380 for curve25519.
+//
+// PointToUniformVartime returns the representative of a point, from which
+// UniformToPointVartime recovers it. Only about half of the points have a
+// representative: PointToUniformVartime returns ErrNotRepresentable for the others,
+// in which case the caller should pick another point, typically another
+// ephemeral key.
+//
+// For the representatives to look random, the encoded points must be uniform
+// over the whole curve and not only over the prime-order subgroup, otherwise
+// decoding them and checking their order tells them apart from random
+// strings. Points of the prime-order subgroup can be made uniform by adding a
+// random point of small order, which the receiver removes by multiplying
+// by the cofactor.
+//
+// The arithmetic is done with big.Int, and is therefore not constant time,
+// hence the Vartime suffix of the functions: their timing leaks information
+// about the points and representatives, which must not be secret.
+package elligator
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+)
+
+// Suite represents the set of functionalities needed by the package
+// elligator.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+// ErrNotRepresentable is returned by PointToUniformVartime for points that have no
+// representative.
+var ErrNotRepresentable = errors.New("elligator: point has no representative")
+
+var errUnsupported = errors.New("elligator: unsupported group")
+
+var (
+	p = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	// J is the Montgomery A parameter of curve25519
+	J = big.NewInt(486662)
+	// d = -121665/121666 is the Edwards d parameter of edwards25519
+	d = modp(new(big.Int).Mul(big.NewInt(-121665), new(big.Int).ModInverse(big.NewInt(121666), p)))
+	// c1 = sqrt(-486664) with sgn0 equal to 0, for the birational map between
+	// the Montgomery and Edwards coordinates
+	c1 = sqrtSign(new(big.Int).Sub(p, big.NewInt(486664)), 0)
+	// halfP = (p-1)/2 is the largest of the canonical square roots
+	halfP = new(big.Int).Rsh(p, 1)
+)
+
+// PointToUniformVartime returns a representative of the point P, with its
+// two unused most significant bits set at random. It returns
+// ErrNotRepresentable if P has no representative. It runs in variable time,
+// both in its arithmetic and in which points it rejects early, so its timing
+// reveals information about P.
+func PointToUniformVartime(suite Suite, P kyber.Point) ([]byte, error) {
+	if suite.String() != "Ed25519" {
+		return nil, errUnsupported
+	}
+	buf, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	x, y, ok := decode(buf)
+	if !ok {
+		return nil, errors.New("elligator: invalid point")
+	}
+
+	// (s, t) = ((1+y)/(1-y), c1·s/x) in Montgomery coordinates
+	oneMinusY := modp(new(big.Int).Sub(big.NewInt(1), y))
+	if oneMinusY.Sign() == 0 || x.Sign() == 0 {
+		return nil, ErrNotRepresentable
+	}
+	s := new(big.Int).Add(big.NewInt(1), y)
+	modp(s.Mul(s, inv(oneMinusY)))
+	t := new(big.Int).Mul(c1, s)
+	modp(t.Mul(t, inv(x)))
+
+	// the map picks t with sgn0 1 for x1 = s, for which r^2 = -(s+J)/(2s),
+	// and t with sgn0 0 for x2 = s, for which r^2 = -s/(2(s+J))
+	sPlusJ := modp(new(big.Int).Add(s, J))
+	if s.Sign() == 0 || sPlusJ.Sign() == 0 {
+		return nil, ErrNotRepresentable
+	}
+	num, den := sPlusJ, s
+	if t.Bit(0) == 0 {
+		num, den = s, sPlusJ
+	}
+	r2 := new(big.Int).Neg(num)
+	r2.Mul(r2, inv(modp(new(big.Int).Lsh(den, 1))))
+	modp(r2)
+	if !isSquare(r2) {
+		return nil, ErrNotRepresentable
+	}
+	r := new(big.Int).ModSqrt(r2, p)
+	if r.Cmp(halfP) > 0 {
+		r.Sub(p, r)
+	}
+
+	// check the round trip, which fails in the exceptional cases of the map
+	Q, err := mapToPoint(suite, r)
+	if err != nil || !Q.Equal(P) {
+		return nil, ErrNotRepresentable
+	}
+	out := reverse(r.FillBytes(make([]byte, 32)))
+	var top [1]byte
+	random.Bytes(top[:], suite.RandomStream())
+	out[31] |= top[0] & 0xc0
+	return out, nil
+}
+
+// UniformToPointVartime returns the point of which b is the representative.
+// Any string of 32 bytes is the representative of a point. It runs in
+// variable time, so its timing reveals information about b.
+func UniformToPointVartime(suite Suite, b []byte) (kyber.Point, error) {
+	if suite.String() != "Ed25519" {
+		return nil, errUnsupported
+	}
+	if len(b) != 32 {
+		return nil, errors.New("elligator: representative must be 32 bytes long")
+	}
+	buf := reverse(b)
+	buf[0] &= 0x3f
+	return mapToPoint(suite, modp(new(big.Int).SetBytes(buf)))
+}
+
+// mapToPoint applies the Elligator 2 map to the field element r and converts
+// the result to edwards25519 coordinates, as in RFC 9380 appendix G.2.
+func mapToPoint(suite Suite, r *big.Int) (kyber.Point, error) {
+	curve := func(x *big.Int) *big.Int {
+		// x^3 + J·x^2 + x
+		gx := new(big.Int).Add(x, J)
+		gx.Mul(gx, x)
+		gx.Add(gx, big.NewInt(1))
+		return modp(gx.Mul(gx, x))
+	}
+
+	// x1 = -J / (1 + 2·r^2), or -J if the denominator is zero
+	tv := new(big.Int).Mul(r, r)
+	modp(tv.Lsh(tv, 1).Add(tv, big.NewInt(1)))
+	x1 := new(big.Int).Neg(J)
+	if tv.Sign() != 0 {
+		x1.Mul(x1, inv(tv))
+	}
+	modp(x1)
+	var s, t *big.Int
+	if gx1 := curve(x1); isSquare(gx1) {
+		s, t = x1, sqrtSign(gx1, 1)
+	} else {
+		x2 := new(big.Int).Neg(x1)
+		modp(x2.Sub(x2, J))
+		s, t = x2, sqrtSign(curve(x2), 0)
+	}
+
+	// (x, y) = (c1·s/t, (s-1)/(s+1)), or the identity for the exceptional
+	// cases
+	x, y := big.NewInt(0), big.NewInt(1)
+	sPlusOne := modp(new(big.Int).Add(s, big.NewInt(1)))
+	if t.Sign() != 0 && sPlusOne.Sign() != 0 {
+		x.Mul(c1, s)
+		modp(x.Mul(x, inv(t)))
+		y.Sub(s, big.NewInt(1))
+		modp(y.Mul(y, inv(sPlusOne)))
+	}
+
+	b := reverse(y.FillBytes(make([]byte, 32)))
+	b[31] |= byte(x.Bit(0)) << 7
+	P := suite.Point()
+	if err := P.UnmarshalBinary(b); err != nil {
+		return nil, errors.New("elligator: invalid point")
+	}
+	return P, nil
+}
+
+// decode returns the affine coordinates of the encoded edwards25519 point.
+func decode(buf []byte) (x, y *big.Int, ok bool) {
+	if len(buf) != 32 {
+		return nil, nil, false
+	}
+	b := reverse(buf)
+	sign := uint(b[0] >> 7)
+	b[0] &= 0x7f
+	y = modp(new(big.Int).SetBytes(b))
+
+	// x^2 = (y^2 - 1) / (d·y^2 + 1)
+	y2 := new(big.Int).Mul(y, y)
+	num := modp(new(big.Int).Sub(y2, big.NewInt(1)))
+	den := modp(new(big.Int).Add(new(big.Int).Mul(d, y2), big.NewInt(1)))
+	x2 := modp(num.Mul(num, inv(den)))
+	if !isSquare(x2) {
+		return nil, nil, false
+	}
+	return sqrtSign(x2, sign), y, true
+}
+
+func modp(z *big.Int) *big.Int { return z.Mod(z, p) }
+
+func inv(z *big.Int) *big.Int { return new(big.Int).ModInverse(z, p) }
+
+func isSquare(z *big.Int) bool {
+	return z.Sign() == 0 || big.Jacobi(z, p) == 1
+}
+
+// sqrtSign returns the square root of the square z whose parity is sign.
+func sqrtSign(z *big.Int, sign uint) *big.Int {
+	r := new(big.Int).ModSqrt(z, p)
+	if r.Sign() != 0 && r.Bit(0) != sign {
+		r.Sub(p, r)
+	}
+	return r
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,127 @@
+package elligator
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/pairing/bn256"
+	"github.com/dedis/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// randomPoint returns a point uniform over the whole curve, by decoding
+// random strings until one is a valid encoding.
+func randomPoint() kyber.Point {
+	P := suite.Point()
+	buf := make([]byte, 32)
+	for {
+		random.Bytes(buf, suite.RandomStream())
+		if P.UnmarshalBinary(buf) == nil {
+			return P
+		}
+	}
+}
+
+// representable returns a random point with a representative, together with
+// the representative.
+func representable(t *testing.T) (kyber.Point, []byte) {
+	for {
+		P := randomPoint()
+		b, err := PointToUniformVartime(suite, P)
+		if err == ErrNotRepresentable {
+			continue
+		}
+		require.Nil(t, err)
+		return P, b
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	const n = 1000
+	failures := 0
+	for i := 0; i < n; i++ {
+		P := randomPoint()
+		b, err := PointToUniformVartime(suite, P)
+		if err == ErrNotRepresentable {
+			failures++
+			continue
+		}
+		require.Nil(t, err)
+		require.Len(t, b, 32)
+		Q, err := UniformToPointVartime(suite, b)
+		require.Nil(t, err)
+		require.True(t, P.Equal(Q))
+	}
+	// about half of the points have a representative
+	require.True(t, failures > n/3 && failures < 2*n/3, "%d failures", failures)
+
+	// points of the prime-order subgroup too
+	P := suite.Point().Pick(suite.RandomStream())
+	for {
+		b, err := PointToUniformVartime(suite, P)
+		if err == nil {
+			Q, err := UniformToPointVartime(suite, b)
+			require.Nil(t, err)
+			require.True(t, P.Equal(Q))
+			break
+		}
+		P.Add(P, suite.Point().Base())
+	}
+}
+
+func TestUniformToPointVartime(t *testing.T) {
+	buf := make([]byte, 32)
+	for i := 0; i < 100; i++ {
+		random.Bytes(buf, suite.RandomStream())
+		_, err := UniformToPointVartime(suite, buf)
+		require.Nil(t, err)
+	}
+	// the two most significant bits are ignored
+	_, b := representable(t)
+	P, err := UniformToPointVartime(suite, b)
+	require.Nil(t, err)
+	b[31] ^= 0xc0
+	Q, err := UniformToPointVartime(suite, b)
+	require.Nil(t, err)
+	require.True(t, P.Equal(Q))
+
+	_, err = UniformToPointVartime(suite, b[:31])
+	require.NotNil(t, err)
+}
+
+// TestUniformity checks with a chi-square test that the bytes of the
+// representatives of random points are uniformly distributed.
+func TestUniformity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping uniformity test in short mode")
+	}
+	const n = 10000
+	var counts [32][256]float64
+	for i := 0; i < n; i++ {
+		_, b := representable(t)
+		for j, v := range b {
+			counts[j][v]++
+		}
+	}
+	// the 99.9th percentile of the chi-square distribution with 255 degrees
+	// of freedom is about 330.5, which gives some slack to the 32 tests
+	const expected = n / 256.0
+	for j := range counts {
+		chi2 := 0.0
+		for _, c := range counts[j] {
+			chi2 += (c - expected) * (c - expected) / expected
+		}
+		require.True(t, chi2 < 350, "byte %d: chi-square %f", j, chi2)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	other := bn256.NewSuiteG1()
+	_, err := PointToUniformVartime(other, other.Point().Pick(other.RandomStream()))
+	require.NotNil(t, err)
+	_, err = UniformToPointVartime(other, make([]byte, 32))
+	require.NotNil(t, err)
+}
@@ -78,6 +78,13 @@ func StringHexToScalar(group kyber.Group, str string) (kyber.Scalar, error) {
 	return ReadHexScalar(group, strings.NewReader(str))
 }
 
+// LittleEndianScalars tells whether the group encodes its scalars in
+// little-endian byte order, like edwards25519, rather than in big-endian.
+func LittleEndianScalars(group kyber.Group) bool {
+	b, _ := group.Scalar().One().MarshalBinary()
+	return len(b) > 1 && b[0] == 1
+}
+
 func getHex(r io.Reader, l int) ([]byte, error) {
 	bufHex := make([]byte, l*2)
 	bufByte := make([]byte, l)
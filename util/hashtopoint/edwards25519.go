@@ -0,0 +1,81 @@
+package hashtopoint
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/dedis/kyber"
+)
+
+var (
+	fieldEdwards25519 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	montgomeryJ       = big.NewInt(486662)
+	// sqrt(-486664) with sgn0 equal to 0, for the map to Edwards coordinates
+	edwardsC1 = sqrtSign(new(big.Int).Sub(fieldEdwards25519, big.NewInt(486664)), fieldEdwards25519, 0)
+)
+
+// mapEdwards25519 applies the Elligator 2 map to curve25519 and converts the
+// result to edwards25519 coordinates, as in RFC 9380 appendix G.2.
+func mapEdwards25519(suite Suite, u *big.Int) (kyber.Point, error) {
+	p := fieldEdwards25519
+	modp := func(z *big.Int) *big.Int { return z.Mod(z, p) }
+	inv := func(z *big.Int) *big.Int { return new(big.Int).ModInverse(z, p) }
+	curve := func(x *big.Int) *big.Int {
+		// x^3 + J·x^2 + x
+		gx := new(big.Int).Add(x, montgomeryJ)
+		gx.Mul(gx, x)
+		gx.Add(gx, big.NewInt(1))
+		return modp(gx.Mul(gx, x))
+	}
+
+	// x1 = -J / (1 + 2·u^2), or -J if the denominator is zero
+	tv := new(big.Int).Mul(u, u)
+	modp(tv.Lsh(tv, 1).Add(tv, big.NewInt(1)))
+	x1 := new(big.Int).Neg(montgomeryJ)
+	if tv.Sign() != 0 {
+		x1.Mul(x1, inv(tv))
+	}
+	modp(x1)
+	var s, t *big.Int
+	if gx1 := curve(x1); isSquare(gx1, p) {
+		s, t = x1, sqrtSign(gx1, p, 1)
+	} else {
+		x2 := new(big.Int).Neg(x1)
+		modp(x2.Sub(x2, montgomeryJ))
+		s, t = x2, sqrtSign(curve(x2), p, 0)
+	}
+
+	// (v, w) = (sqrt(-486664)·s/t, (s-1)/(s+1)), or the identity for the
+	// exceptional cases
+	x, y := big.NewInt(0), big.NewInt(1)
+	sPlusOne := modp(new(big.Int).Add(s, big.NewInt(1)))
+	if t.Sign() != 0 && sPlusOne.Sign() != 0 {
+		x.Mul(edwardsC1, s)
+		modp(x.Mul(x, inv(t)))
+		y.Sub(s, big.NewInt(1))
+		modp(y.Mul(y, inv(sPlusOne)))
+	}
+
+	// encode (x, y) and decode it as a point
+	b := reverse(y.FillBytes(make([]byte, 32)))
+	b[31] |= byte(sgn0(x)) << 7
+	P := suite.Point()
+	if err := P.UnmarshalBinary(b); err != nil {
+		return nil, errors.New("hashtopoint: invalid point")
+	}
+	return P, nil
+}
+
+func isSquare(z, p *big.Int) bool {
+	return z.Sign() == 0 || big.Jacobi(z, p) == 1
+}
+
+// sqrtSign returns the square root modulo p of the square z whose sgn0 is
+// sign.
+func sqrtSign(z, p *big.Int, sign uint) *big.Int {
+	r := new(big.Int).ModSqrt(z, p)
+	if r.Sign() != 0 && sgn0(r) != sign {
+		r.Sub(p, r)
+	}
+	return r
+}
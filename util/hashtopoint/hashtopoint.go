@@ -0,0 +1,239 @@
+// Package hashtopoint hashes byte strings to points and scalars following
+// RFC 9380. The supported groups and their suites are:
+//
+//	Ed25519       edwards25519_XMD:SHA-512_ELL2_RO_ and _NU_
+//	P256          P256_XMD:SHA-256_SSWU_RO_ and _NU_
+//	P521          P521_XMD:SHA-512_SSWU_RO_ and _NU_
+//	Ristretto255  hash_to_ristretto255 of RFC 9496, with expand_message_xmd
+//	              and SHA-512
+//
+// The mapping is selected from the name of the group, so the functions work
+// with any suite built on one of these groups. Other groups return an error.
+//
+// The domain separation tag dst must be unique to the application and to its
+// use of the hash function, as RFC 9380 section 3.1 describes.
+package hashtopoint
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/encoding"
+)
+
+// Suite represents the set of functionalities needed by the package
+// hashtopoint.
+type Suite interface {
+	kyber.Group
+}
+
+var errUnsupported = errors.New("hashtopoint: unsupported group")
+
+// mapping describes the hash to curve suite of a group.
+type mapping struct {
+	hash func() hash.Hash
+	// L is the number of bytes per field element, ceil((ceil(log2(p))+k)/8)
+	L int
+	// mapToCurve maps a field element to a point, before clearing the
+	// cofactor.
+	mapToCurve func(suite Suite, u *big.Int) (kyber.Point, error)
+	field      *big.Int
+	cofactor   int64
+}
+
+var mappings = map[string]*mapping{
+	"Ed25519": {sha512.New, 48, mapEdwards25519, fieldEdwards25519, 8},
+	"P256":    {sha256.New, 48, mapP256, fieldP256, 1},
+	"P521":    {sha512.New, 98, mapP521, fieldP521, 1},
+}
+
+// HashToPoint implements the hash_to_curve function of RFC 9380, whose
+// output is indistinguishable from a random point of the prime-order group.
+func HashToPoint(suite Suite, dst, msg []byte) (kyber.Point, error) {
+	if suite.String() == "Ristretto255" {
+		return hashToRistretto(suite, dst, msg)
+	}
+	m, ok := mappings[suite.String()]
+	if !ok {
+		return nil, errUnsupported
+	}
+	u, err := hashToField(m.hash, m.field, m.L, dst, msg, 2)
+	if err != nil {
+		return nil, err
+	}
+	Q0, err := m.mapToCurve(suite, u[0])
+	if err != nil {
+		return nil, err
+	}
+	Q1, err := m.mapToCurve(suite, u[1])
+	if err != nil {
+		return nil, err
+	}
+	return m.clearCofactor(suite, Q0.Add(Q0, Q1)), nil
+}
+
+// EncodeToPoint implements the encode_to_curve function of RFC 9380. It is
+// faster than HashToPoint, but its output is not uniformly distributed and
+// only some of the points can be reached.
+func EncodeToPoint(suite Suite, dst, msg []byte) (kyber.Point, error) {
+	m, ok := mappings[suite.String()]
+	if !ok {
+		return nil, errUnsupported
+	}
+	u, err := hashToField(m.hash, m.field, m.L, dst, msg, 1)
+	if err != nil {
+		return nil, err
+	}
+	Q, err := m.mapToCurve(suite, u[0])
+	if err != nil {
+		return nil, err
+	}
+	return m.clearCofactor(suite, Q), nil
+}
+
+// HashToScalar implements the hash_to_field function of RFC 9380 for the
+// scalar field of the group, with the hash function of its hash to curve
+// suite.
+func HashToScalar(suite Suite, dst, msg []byte) (kyber.Scalar, error) {
+	h := sha512.New
+	if m, ok := mappings[suite.String()]; ok {
+		h = m.hash
+	} else if suite.String() != "Ristretto255" {
+		return nil, errUnsupported
+	}
+	order, err := scalarOrder(suite)
+	if err != nil {
+		return nil, err
+	}
+	// L = ceil((ceil(log2(order)) + 128) / 8)
+	L := (order.BitLen() + 128 + 7) / 8
+	u, err := hashToField(h, order, L, dst, msg, 1)
+	if err != nil {
+		return nil, err
+	}
+	return scalarFromInt(suite, u[0])
+}
+
+func (m *mapping) clearCofactor(suite Suite, P kyber.Point) kyber.Point {
+	if m.cofactor == 1 {
+		return P
+	}
+	return P.Mul(suite.Scalar().SetInt64(m.cofactor), P)
+}
+
+func hashToRistretto(suite Suite, dst, msg []byte) (kyber.Point, error) {
+	uniform, err := ExpandMessageXMD(sha512.New, dst, msg, 64)
+	if err != nil {
+		return nil, err
+	}
+	P := suite.Point()
+	setter, ok := P.(interface {
+		SetUniformBytes([]byte) error
+	})
+	if !ok {
+		return nil, errUnsupported
+	}
+	if err := setter.SetUniformBytes(uniform); err != nil {
+		return nil, err
+	}
+	return P, nil
+}
+
+// ExpandMessageXMD implements the expand_message_xmd function of RFC 9380
+// with the hash function h, returning n uniformly random bytes.
+func ExpandMessageXMD(h func() hash.Hash, dst, msg []byte, n int) ([]byte, error) {
+	H := h()
+	size := H.Size()
+	ell := (n + size - 1) / size
+	if ell > 255 || n > 65535 {
+		return nil, errors.New("hashtopoint: requested output too long")
+	}
+	if len(dst) > 255 {
+		H.Write([]byte("H2C-OVERSIZE-DST-"))
+		H.Write(dst)
+		dst = H.Sum(nil)
+		H.Reset()
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	H.Write(make([]byte, H.BlockSize()))
+	H.Write(msg)
+	H.Write([]byte{byte(n >> 8), byte(n), 0})
+	H.Write(dstPrime)
+	b0 := H.Sum(nil)
+
+	out := make([]byte, 0, ell*size)
+	bi := make([]byte, size)
+	for i := 1; i <= ell; i++ {
+		// b_i = H(strxor(b_0, b_(i-1)) || i || DST_prime), with b_(0) xor
+		// b_0 replaced by b_0 alone for i = 1
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		H.Reset()
+		H.Write(bi)
+		H.Write([]byte{byte(i)})
+		H.Write(dstPrime)
+		bi = H.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:n], nil
+}
+
+// hashToField returns count elements of the integers modulo p, each derived
+// from L bytes of expand_message_xmd.
+func hashToField(h func() hash.Hash, p *big.Int, L int, dst, msg []byte, count int) ([]*big.Int, error) {
+	uniform, err := ExpandMessageXMD(h, dst, msg, count*L)
+	if err != nil {
+		return nil, err
+	}
+	u := make([]*big.Int, count)
+	for i := range u {
+		u[i] = new(big.Int).SetBytes(uniform[i*L : (i+1)*L])
+		u[i].Mod(u[i], p)
+	}
+	return u, nil
+}
+
+// scalarOrder returns the order of the scalars of the group.
+func scalarOrder(suite Suite) (*big.Int, error) {
+	minusOne := suite.Scalar().Sub(suite.Scalar().Zero(), suite.Scalar().One())
+	b, err := minusOne.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if encoding.LittleEndianScalars(suite) {
+		b = reverse(b)
+	}
+	order := new(big.Int).SetBytes(b)
+	return order.Add(order, big.NewInt(1)), nil
+}
+
+func scalarFromInt(suite Suite, v *big.Int) (kyber.Scalar, error) {
+	b := v.FillBytes(make([]byte, suite.ScalarLen()))
+	if encoding.LittleEndianScalars(suite) {
+		b = reverse(b)
+	}
+	s := suite.Scalar()
+	if err := s.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+// sgn0 is the sign of a field element of a prime field, its parity.
+func sgn0(x *big.Int) uint {
+	return x.Bit(0)
+}
@@ -0,0 +1,91 @@
+package hashtopoint
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+type vector struct {
+	msg, x, y string
+}
+
+// edwards25519Point returns the encoding of the point of affine coordinates
+// given as big-endian hexadecimal strings.
+func edwards25519Point(t *testing.T, v vector) string {
+	x, _ := new(big.Int).SetString(v.x, 16)
+	y, _ := new(big.Int).SetString(v.y, 16)
+	b := reverse(y.FillBytes(make([]byte, 32)))
+	b[31] |= byte(x.Bit(0)) << 7
+	return hex.EncodeToString(b)
+}
+
+// Appendix J.5 of RFC 9380.
+func TestEdwards25519(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	for _, v := range []vector{
+		{"", "3c3da6925a3c3c268448dcabb47ccde5439559d9599646a8260e47b1e4822fc6", "09a6c8561a0b22bef63124c588ce4c62ea83a3c899763af26d795302e115dc21"},
+		{"abc", "608040b42285cc0d72cbb3985c6b04c935370c7361f4b7fbdb1ae7f8c1a8ecad", "1a8395b88338f22e435bbd301183e7f20a5f9de643f11882fb237f88268a5531"},
+	} {
+		P, err := HashToPoint(suite, []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_"), []byte(v.msg))
+		require.Nil(t, err)
+		require.Equal(t, edwards25519Point(t, v), P.String(), "msg %q", v.msg)
+	}
+	for _, v := range []vector{
+		{"", "1ff2b70ecf862799e11b7ae744e3489aa058ce805dd323a936375a84695e76da", "222e314d04a4d5725e9f2aff9fb2a6b69ef375a1214eb19021ceab2d687f0f9b"},
+		{"abc", "5f13cc69c891d86927eb37bd4afc6672360007c63f68a33ab423a3aa040fd2a8", "67732d50f9a26f73111dd1ed5dba225614e538599db58ba30aaea1f5c827fa42"},
+	} {
+		P, err := EncodeToPoint(suite, []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_"), []byte(v.msg))
+		require.Nil(t, err)
+		require.Equal(t, edwards25519Point(t, v), P.String(), "msg %q", v.msg)
+	}
+}
+
+// Appendix K.1 of RFC 9380.
+func TestExpandMessageXMD(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	b, err := ExpandMessageXMD(sha256.New, dst, nil, 0x20)
+	require.Nil(t, err)
+	require.Equal(t, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235", hex.EncodeToString(b))
+	b, err = ExpandMessageXMD(sha256.New, dst, []byte("abc"), 0x20)
+	require.Nil(t, err)
+	require.Equal(t, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615", hex.EncodeToString(b))
+
+	_, err = ExpandMessageXMD(sha512.New, dst, nil, 256*64)
+	require.Error(t, err)
+}
+
+func TestRistretto255(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ristretto255()
+	dst := []byte("hashtopoint-test")
+	P, err := HashToPoint(suite, dst, []byte("msg"))
+	require.Nil(t, err)
+	Q, err := HashToPoint(suite, dst, []byte("msg"))
+	require.Nil(t, err)
+	require.True(t, P.Equal(Q))
+	Q, err = HashToPoint(suite, dst, []byte("other msg"))
+	require.Nil(t, err)
+	require.False(t, P.Equal(Q))
+
+	_, err = EncodeToPoint(suite, dst, []byte("msg"))
+	require.Error(t, err)
+}
+
+func TestHashToScalar(t *testing.T) {
+	for _, suite := range []Suite{edwards25519.NewBlakeSHA256Ed25519(), edwards25519.NewBlakeSHA256Ristretto255()} {
+		dst := []byte("hashtopoint-test")
+		s1, err := HashToScalar(suite, dst, []byte("msg"))
+		require.Nil(t, err)
+		s2, err := HashToScalar(suite, dst, []byte("msg"))
+		require.Nil(t, err)
+		require.True(t, s1.Equal(s2))
+		s2, err = HashToScalar(suite, []byte("other dst"), []byte("msg"))
+		require.Nil(t, err)
+		require.False(t, s1.Equal(s2))
+	}
+}
@@ -0,0 +1,69 @@
+package hashtopoint
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/dedis/kyber"
+)
+
+var (
+	fieldP256 = elliptic.P256().Params().P
+	fieldP521 = elliptic.P521().Params().P
+
+	mapP256 = mapSSWU(elliptic.P256(), big.NewInt(-10))
+	mapP521 = mapSSWU(elliptic.P521(), big.NewInt(-4))
+)
+
+// mapSSWU returns the simplified Shallue-van de Woestijne-Ulas map of RFC
+// 9380 section 6.6.2 to a NIST curve, for which A = -3, with the constant Z.
+func mapSSWU(c elliptic.Curve, Z *big.Int) func(Suite, *big.Int) (kyber.Point, error) {
+	p := c.Params().P
+	B := c.Params().B
+	A := new(big.Int).Sub(p, big.NewInt(3))
+	Z = new(big.Int).Mod(Z, p)
+	modp := func(z *big.Int) *big.Int { return z.Mod(z, p) }
+	curve := func(x *big.Int) *big.Int {
+		// x^3 + A·x + B
+		gx := new(big.Int).Mul(x, x)
+		gx.Add(gx, A)
+		gx.Mul(gx, x)
+		return modp(gx.Add(gx, B))
+	}
+
+	return func(suite Suite, u *big.Int) (kyber.Point, error) {
+		// tv1 = 1 / (Z^2·u^4 + Z·u^2)
+		zu2 := new(big.Int).Mul(u, u)
+		modp(zu2.Mul(zu2, Z))
+		tv1 := new(big.Int).Mul(zu2, zu2)
+		modp(tv1.Add(tv1, zu2))
+
+		// x1 = (-B / A)·(1 + tv1), or B / (Z·A) if tv1 is zero
+		x1 := new(big.Int)
+		if tv1.Sign() == 0 {
+			x1.Mul(Z, A)
+			x1.ModInverse(x1, p)
+			x1.Mul(x1, B)
+		} else {
+			tv1.ModInverse(tv1, p)
+			x1.Neg(B)
+			x1.Mul(x1, new(big.Int).ModInverse(A, p))
+			x1.Mul(x1, tv1.Add(tv1, big.NewInt(1)))
+		}
+		modp(x1)
+
+		x, gx := x1, curve(x1)
+		if !isSquare(gx, p) {
+			x = modp(new(big.Int).Mul(zu2, x1))
+			gx = curve(x)
+		}
+		y := sqrtSign(gx, p, sgn0(u))
+
+		P := suite.Point()
+		if err := P.UnmarshalBinary(elliptic.Marshal(c, x, y)); err != nil {
+			return nil, errors.New("hashtopoint: invalid point")
+		}
+		return P, nil
+	}
+}
@@ -0,0 +1,54 @@
+// +build vartime
+
+package hashtopoint
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/group/nist"
+	"github.com/stretchr/testify/require"
+)
+
+// Appendix J.1 of RFC 9380.
+func TestP256(t *testing.T) {
+	suite := nist.NewBlakeSHA256P256()
+	for _, v := range []vector{
+		{"", "2c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4", "8a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415"},
+		{"abc", "0bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f", "5c41b3d0731a27a7b14bc0bf0ccded2d8751f83493404c84a88e71ffd424212e"},
+	} {
+		P, err := HashToPoint(suite, []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_"), []byte(v.msg))
+		require.Nil(t, err)
+		b, _ := P.MarshalBinary()
+		require.Equal(t, "04"+v.x+v.y, hex.EncodeToString(b), "msg %q", v.msg)
+	}
+	P, err := EncodeToPoint(suite, []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_NU_"), nil)
+	require.Nil(t, err)
+	b, _ := P.MarshalBinary()
+	require.Equal(t, "04f871caad25ea3b59c16cf87c1894902f7e7b2c822c3d3f73596c5ace8ddd14d187b9ae23335bee057b99bac1e68588b18b5691af476234b8971bc4f011ddc99b", hex.EncodeToString(b))
+
+	s, err := HashToScalar(suite, []byte("dst"), []byte("msg"))
+	require.Nil(t, err)
+	require.False(t, s.Equal(suite.Scalar().Zero()))
+
+	// groups without a mapping are not supported
+	qr := nist.NewBlakeSHA256QR512()
+	_, err = HashToPoint(qr, []byte("dst"), nil)
+	require.Error(t, err)
+	_, err = HashToScalar(qr, []byte("dst"), nil)
+	require.Error(t, err)
+}
+
+// Appendix J.3 of RFC 9380.
+func TestP521(t *testing.T) {
+	suite := nist.NewBlakeSHA512P521()
+	for _, v := range []vector{
+		{"", "00fd767cebb2452030358d0e9cf907f525f50920c8f607889a6a35680727f64f4d66b161fafeb2654bea0d35086bec0a10b30b14adef3556ed9f7f1bc23cecc9c088", "0169ba78d8d851e930680322596e39c78f4fe31b97e57629ef6460ddd68f8763fd7bd767a4e94a80d3d21a3c2ee98347e024fc73ee1c27166dc3fe5eeef782be411d"},
+		{"abc", "002f89a1677b28054b50d15e1f81ed6669b5a2158211118ebdef8a6efc77f8ccaa528f698214e4340155abc1fa08f8f613ef14a043717503d57e267d57155cf784a4", "010e0be5dc8e753da8ce51091908b72396d3deed14ae166f66d8ebf0a4e7059ead169ea4bead0232e9b700dd380b316e9361cfdba55a08c73545563a80966ecbb86d"},
+	} {
+		P, err := HashToPoint(suite, []byte("QUUX-V01-CS02-with-P521_XMD:SHA-512_SSWU_RO_"), []byte(v.msg))
+		require.Nil(t, err)
+		b, _ := P.MarshalBinary()
+		require.Equal(t, "04"+v.x+v.y, hex.EncodeToString(b), "msg %q", v.msg)
+	}
+}
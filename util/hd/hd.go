@@ -0,0 +1,162 @@
+// Package hd implements hierarchical deterministic key derivation in the style
+// of BIP-32, following SLIP-10 for the groups other than secp256k1.
+//
+// A master key is derived from a seed with MasterKey, and any number of child
+// keys from a key with Derive, so a whole tree of keys can be recovered from
+// the seed alone. Indices from Hardened on give hardened children, which
+// cannot be linked to the parent public key.
+//
+// The groups supported are P256, with the BIP-32 derivation of SLIP-10, and
+// Ed25519. For Ed25519, SLIP-10 only defines hardened derivation, so every
+// index is treated as hardened: Derive(i) and Derive(i + Hardened) give the
+// same key, and paths are usually written with a ' after every index.
+package hd
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed by the package hd.
+type Suite interface {
+	kyber.Group
+}
+
+// Hardened is the first index of the hardened children.
+const Hardened uint32 = 0x80000000
+
+// HDKey is a node of a key derivation tree.
+type HDKey struct {
+	suite     Suite
+	key       []byte
+	chainCode []byte
+	private   kyber.Scalar
+}
+
+var errUnsupported = errors.New("hd: unsupported group")
+
+// curveKeys gives the HMAC key of the master key derivation of each group.
+var curveKeys = map[string]string{
+	"P256":    "Nist256p1 seed",
+	"Ed25519": "ed25519 seed",
+}
+
+// MasterKey derives the root of the tree of keys from seed, which should have
+// at least 128 bits of entropy.
+func MasterKey(suite Suite, seed []byte) (*HDKey, error) {
+	curveKey, ok := curveKeys[suite.String()]
+	if !ok {
+		return nil, errUnsupported
+	}
+	I := hmacSHA512([]byte(curveKey), seed)
+	if suite.String() == "P256" {
+		// restart from I until IL is a valid private key
+		for !validP256(I[:32]) {
+			I = hmacSHA512([]byte(curveKey), I)
+		}
+	}
+	return newKey(suite, I)
+}
+
+// Derive returns the child of k at the given index.
+func (k *HDKey) Derive(index uint32) (*HDKey, error) {
+	if k.suite.String() == "Ed25519" {
+		index |= Hardened
+	}
+	data := make([]byte, 0, 37)
+	if index >= Hardened {
+		data = append(append(data, 0), k.key...)
+	} else {
+		pub, err := compressedP256(k.Public())
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, pub...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	I := hmacSHA512(k.chainCode, data)
+
+	if k.suite.String() == "Ed25519" {
+		return newKey(k.suite, I)
+	}
+	// child key = IL + parent key, retrying with 0x01 || IR || index while
+	// the result is invalid
+	order := elliptic.P256().Params().N
+	for {
+		if validP256(I[:32]) {
+			child := new(big.Int).SetBytes(I[:32])
+			child.Add(child, new(big.Int).SetBytes(k.key))
+			child.Mod(child, order)
+			if child.Sign() != 0 {
+				copy(I[:32], child.FillBytes(make([]byte, 32)))
+				return newKey(k.suite, I)
+			}
+		}
+		data = append(append([]byte{1}, I[32:]...), data[len(data)-4:]...)
+		I = hmacSHA512(k.chainCode, data)
+	}
+}
+
+// Private returns the private key of the node.
+func (k *HDKey) Private() kyber.Scalar {
+	return k.private.Clone()
+}
+
+// Public returns the public key of the node.
+func (k *HDKey) Public() kyber.Point {
+	return k.suite.Point().Mul(k.private, nil)
+}
+
+// ChainCode returns the chain code of the node, which must be kept secret
+// as it allows to derive the non-hardened children of a public key.
+func (k *HDKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode...)
+}
+
+// newKey builds the node whose key and chain code are the halves of I.
+func newKey(suite Suite, I []byte) (*HDKey, error) {
+	k := &HDKey{suite: suite, key: I[:32], chainCode: I[32:]}
+	k.private = suite.Scalar()
+	if suite.String() == "Ed25519" {
+		// the key is the seed of an EdDSA key, as in RFC 8032
+		h := sha512.Sum512(k.key)
+		h[0] &= 248
+		h[31] &= 127
+		h[31] |= 64
+		k.private.SetBytes(h[:32])
+		return k, nil
+	}
+	if err := k.private.UnmarshalBinary(k.key); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func validP256(b []byte) bool {
+	v := new(big.Int).SetBytes(b)
+	return v.Sign() != 0 && v.Cmp(elliptic.P256().Params().N) < 0
+}
+
+// compressedP256 returns the SEC 1 compressed encoding of a P-256 point.
+func compressedP256(P kyber.Point) ([]byte, error) {
+	b, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 65 || b[0] != 4 {
+		return nil, errors.New("hd: unexpected point encoding")
+	}
+	return append([]byte{2 | b[64]&1}, b[1:33]...), nil
+}
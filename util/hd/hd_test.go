@@ -0,0 +1,66 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+type vector struct {
+	path           []uint32
+	chainCode, key string
+	public         string
+}
+
+func checkVectors(t *testing.T, suite Suite, seed string, vectors []vector, public func(*HDKey) string) {
+	s, err := hex.DecodeString(seed)
+	require.Nil(t, err)
+	for _, v := range vectors {
+		k, err := MasterKey(suite, s)
+		require.Nil(t, err)
+		for _, i := range v.path {
+			k, err = k.Derive(i)
+			require.Nil(t, err)
+		}
+		require.Equal(t, v.chainCode, hex.EncodeToString(k.ChainCode()), "path %v", v.path)
+		require.Equal(t, v.key, hex.EncodeToString(k.key), "path %v", v.path)
+		require.Equal(t, v.public, public(k), "path %v", v.path)
+	}
+}
+
+// Test vector 1 for ed25519 of SLIP-10.
+func TestEd25519(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	checkVectors(t, suite, "000102030405060708090a0b0c0d0e0f", []vector{
+		{nil, "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", "00a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{[]uint32{Hardened}, "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", "008c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+		{[]uint32{Hardened, 1 + Hardened}, "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2", "001932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"},
+	}, func(k *HDKey) string {
+		b, _ := k.Public().MarshalBinary()
+		return "00" + hex.EncodeToString(b)
+	})
+}
+
+func TestEd25519HardenedOnly(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	k, err := MasterKey(suite, []byte("a seed of sixteen bytes at least"))
+	require.Nil(t, err)
+	c1, err := k.Derive(3)
+	require.Nil(t, err)
+	c2, err := k.Derive(3 + Hardened)
+	require.Nil(t, err)
+	require.True(t, c1.Public().Equal(c2.Public()))
+	require.Equal(t, c1.ChainCode(), c2.ChainCode())
+
+	c3, err := k.Derive(4)
+	require.Nil(t, err)
+	require.False(t, c1.Public().Equal(c3.Public()))
+	require.True(t, c3.Public().Equal(suite.Point().Mul(c3.Private(), nil)))
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := MasterKey(edwards25519.NewBlakeSHA256Ristretto255(), []byte("seed"))
+	require.Error(t, err)
+}
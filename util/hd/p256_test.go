@@ -0,0 +1,37 @@
+// +build vartime
+
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/group/nist"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vector 1 for nist256p1 of SLIP-10.
+func TestP256(t *testing.T) {
+	suite := nist.NewBlakeSHA256P256()
+	checkVectors(t, suite, "000102030405060708090a0b0c0d0e0f", []vector{
+		{nil, "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea", "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2", "0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8"},
+		{[]uint32{Hardened}, "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c", "0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c"},
+		{[]uint32{Hardened, 1}, "4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c", "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129", "03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844"},
+		{[]uint32{Hardened, 1, 2 + Hardened}, "98c7514f562e64e74170cc3cf304ee1ce54d6b6da4f880f313e8204c2a185318", "694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7", "0359cf160040778a4b14c5f4d7b76e327ccc8c4a6086dd9451b7482b5a4972dda0"},
+	}, func(k *HDKey) string {
+		b, _ := compressedP256(k.Public())
+		return hex.EncodeToString(b)
+	})
+}
+
+func TestP256NonHardened(t *testing.T) {
+	suite := nist.NewBlakeSHA256P256()
+	k, err := MasterKey(suite, []byte("a seed of sixteen bytes at least"))
+	require.Nil(t, err)
+	c1, err := k.Derive(7)
+	require.Nil(t, err)
+	c2, err := k.Derive(7 + Hardened)
+	require.Nil(t, err)
+	require.False(t, c1.Public().Equal(c2.Public()))
+	require.True(t, c1.Public().Equal(suite.Point().Mul(c1.Private(), nil)))
+}
@@ -0,0 +1,48 @@
+// Package kdf derives keys with HKDF, RFC 5869, instantiated with the hash
+// function of a suite.
+package kdf
+
+import (
+	"errors"
+	"io"
+
+	"github.com/dedis/kyber"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Suite represents the set of functionalities needed by the package kdf.
+type Suite interface {
+	kyber.HashFactory
+}
+
+// KeyPairSuite represents the set of functionalities needed by DeriveKeyPair.
+type KeyPairSuite interface {
+	kyber.Group
+	kyber.HashFactory
+}
+
+// HKDF returns length bytes derived from ikm with the salt and info of HKDF.
+// It panics if length is larger than 255 times the size of the hash, the limit
+// of HKDF.
+func HKDF(suite Suite, ikm, salt, info []byte, length int) []byte {
+	if length > 255*suite.Hash().Size() {
+		panic("kdf: requested length too large")
+	}
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(suite.Hash, ikm, salt, info), out); err != nil {
+		panic("kdf: " + err.Error())
+	}
+	return out
+}
+
+// DeriveKeyPair deterministically derives a key pair from ikm, which must
+// hold enough entropy for the result to be used as a long-term key.
+func DeriveKeyPair(suite KeyPairSuite, ikm []byte) (kyber.Scalar, kyber.Point, error) {
+	// 128 bits more than the scalar make the bias of the reduction negligible
+	b := HKDF(suite, ikm, nil, []byte("kyber-derive-keypair "+suite.String()), suite.ScalarLen()+16)
+	private := suite.Scalar().SetBytes(b)
+	if private.Equal(suite.Scalar().Zero()) {
+		return nil, nil, errors.New("kdf: derived a zero private key")
+	}
+	return private, suite.Point().Mul(private, nil), nil
+}
@@ -0,0 +1,164 @@
+// Package keywrap exports and imports private keys, either encrypted under a
+// wrapping key with the AES key wrap algorithm of RFC 3394, or split in
+// Shamir secret shares of which a threshold is needed to recover the key.
+package keywrap
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/share"
+)
+
+// Suite represents the set of functionalities needed by the package keywrap.
+type Suite interface {
+	kyber.Group
+	kyber.Random
+}
+
+// defaultIV is the initial value of section 2.2.3.1 of RFC 3394.
+var defaultIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+var (
+	errKeySize     = errors.New("keywrap: wrapping key must be 32 bytes long")
+	errLength      = errors.New("keywrap: invalid length of wrapped data")
+	errUnwrap      = errors.New("keywrap: integrity check failed")
+	errScalarSize  = errors.New("keywrap: scalar length must be a multiple of 8 and at least 16")
+	errThreshold   = errors.New("keywrap: invalid threshold")
+	errShareLength = errors.New("keywrap: invalid share length")
+)
+
+// WrapKey encrypts the private key priv under the 32-byte wrappingKey with
+// AES-256 key wrap. The binary encoding of the scalars of the group must be
+// a multiple of 8 bytes long, as RFC 3394 requires.
+func WrapKey(suite Suite, priv kyber.Scalar, wrappingKey []byte) ([]byte, error) {
+	if len(wrappingKey) != 32 {
+		return nil, errKeySize
+	}
+	buf, err := priv.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < 16 || len(buf)%8 != 0 {
+		return nil, errScalarSize
+	}
+	return wrap(wrappingKey, buf)
+}
+
+// UnwrapKey decrypts the private key wrapped by WrapKey with the same
+// wrappingKey. It returns an error if the wrapped key was altered.
+func UnwrapKey(suite Suite, wrapped, wrappingKey []byte) (kyber.Scalar, error) {
+	if len(wrappingKey) != 32 {
+		return nil, errKeySize
+	}
+	if len(wrapped) != suite.ScalarLen()+8 {
+		return nil, errLength
+	}
+	buf, err := unwrap(wrappingKey, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	priv := suite.Scalar()
+	if err := priv.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return priv, nil
+}
+
+// wrap implements the key wrap process of section 2.2.1 of RFC 3394.
+func wrap(kek, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(plaintext) / 8
+	out := make([]byte, 8+len(plaintext))
+	copy(out, defaultIV)
+	copy(out[8:], plaintext)
+	A, B := out[:8], make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 1; i <= n; i++ {
+			R := out[8*i : 8*i+8]
+			copy(B, A)
+			copy(B[8:], R)
+			block.Encrypt(B, B)
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(A, binary.BigEndian.Uint64(B[:8])^t)
+			copy(R, B[8:])
+		}
+	}
+	return out, nil
+}
+
+// unwrap implements the key unwrap process of section 2.2.2 of RFC 3394.
+func unwrap(kek, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 24 || len(ciphertext)%8 != 0 {
+		return nil, errLength
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(ciphertext)/8 - 1
+	out := append([]byte{}, ciphertext...)
+	A, B := out[:8], make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			R := out[8*i : 8*i+8]
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(B, binary.BigEndian.Uint64(A)^t)
+			copy(B[8:], R)
+			block.Decrypt(B, B)
+			copy(A, B[:8])
+			copy(R, B[8:])
+		}
+	}
+	if subtle.ConstantTimeCompare(A, defaultIV) != 1 {
+		return nil, errUnwrap
+	}
+	return out[8:], nil
+}
+
+// ShamirWrapKey splits the private key priv in n shares, any t of which
+// recover it with ShamirUnwrapKey while fewer reveal nothing about it. A share
+// is encoded as i || v where the 2-byte big-endian value i is the index of the
+// share and v the binary encoding of its value, as in package tbls.
+func ShamirWrapKey(suite Suite, priv kyber.Scalar, t, n int) ([][]byte, error) {
+	if t < 1 || t > n || n > 0xffff {
+		return nil, errThreshold
+	}
+	poly := share.NewPriPoly(suite, t, priv, suite.RandomStream())
+	shares := make([][]byte, n)
+	for i, s := range poly.Shares(n) {
+		var buf bytes.Buffer
+		if err := binary.Write(&buf, binary.BigEndian, uint16(s.I)); err != nil {
+			return nil, err
+		}
+		if _, err := s.V.MarshalTo(&buf); err != nil {
+			return nil, err
+		}
+		shares[i] = buf.Bytes()
+	}
+	return shares, nil
+}
+
+// ShamirUnwrapKey recovers the private key from at least t of the n shares
+// created by ShamirWrapKey.
+func ShamirUnwrapKey(suite Suite, shares [][]byte, t, n int) (kyber.Scalar, error) {
+	priShares := make([]*share.PriShare, len(shares))
+	for i, s := range shares {
+		if len(s) != 2+suite.ScalarLen() {
+			return nil, errShareLength
+		}
+		v := suite.Scalar()
+		if err := v.UnmarshalBinary(s[2:]); err != nil {
+			return nil, err
+		}
+		priShares[i] = &share.PriShare{I: int(binary.BigEndian.Uint16(s)), V: v}
+	}
+	return share.RecoverSecret(suite, priShares, t, n)
+}
@@ -0,0 +1,78 @@
+package keywrap
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/dedis/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// Section 4.6 of RFC 3394: wrap 256 bits of key data with a 256-bit KEK.
+func TestRFC3394(t *testing.T) {
+	kek, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F")
+	key, _ := hex.DecodeString("00112233445566778899AABBCCDDEEFF000102030405060708090A0B0C0D0E0F")
+	wrapped, err := wrap(kek, key)
+	require.Nil(t, err)
+	require.Equal(t, "28c9f404c4b810f4cbccb35cfb87f8263f5786e2d80ed326cbc7f0e71a99f43bfb988b9b7a02dd21", hex.EncodeToString(wrapped))
+	unwrapped, err := unwrap(kek, wrapped)
+	require.Nil(t, err)
+	require.Equal(t, key, unwrapped)
+}
+
+func TestWrapKey(t *testing.T) {
+	priv := suite.Scalar().Pick(suite.RandomStream())
+	kek := random.Bits(256, false, random.New())
+	wrapped, err := WrapKey(suite, priv, kek)
+	require.Nil(t, err)
+	require.Len(t, wrapped, suite.ScalarLen()+8)
+	unwrapped, err := UnwrapKey(suite, wrapped, kek)
+	require.Nil(t, err)
+	require.True(t, priv.Equal(unwrapped))
+
+	other := random.Bits(256, false, random.New())
+	_, err = UnwrapKey(suite, wrapped, other)
+	require.Equal(t, errUnwrap, err)
+	wrapped[10] ^= 1
+	_, err = UnwrapKey(suite, wrapped, kek)
+	require.Equal(t, errUnwrap, err)
+	_, err = UnwrapKey(suite, wrapped[:len(wrapped)-8], kek)
+	require.Equal(t, errLength, err)
+
+	_, err = WrapKey(suite, priv, kek[:16])
+	require.Equal(t, errKeySize, err)
+}
+
+func TestShamirWrapKey(t *testing.T) {
+	const thr, n = 3, 5
+	priv := suite.Scalar().Pick(suite.RandomStream())
+	shares, err := ShamirWrapKey(suite, priv, thr, n)
+	require.Nil(t, err)
+	require.Len(t, shares, n)
+
+	// any t shares recover the key
+	for _, subset := range [][]int{{0, 1, 2}, {2, 3, 4}, {0, 2, 4}, {4, 1, 3}} {
+		var chosen [][]byte
+		for _, i := range subset {
+			chosen = append(chosen, shares[i])
+		}
+		recovered, err := ShamirUnwrapKey(suite, chosen, thr, n)
+		require.Nil(t, err)
+		require.True(t, priv.Equal(recovered), "subset %v", subset)
+	}
+
+	// t-1 shares are not enough, and interpolating them gives another key
+	_, err = ShamirUnwrapKey(suite, shares[:thr-1], thr, n)
+	require.Error(t, err)
+	guess, err := ShamirUnwrapKey(suite, shares[:thr-1], thr-1, n)
+	require.Nil(t, err)
+	require.False(t, priv.Equal(guess))
+
+	_, err = ShamirUnwrapKey(suite, [][]byte{shares[0][:10]}, 1, n)
+	require.Equal(t, errShareLength, err)
+	_, err = ShamirWrapKey(suite, priv, n+1, n)
+	require.Equal(t, errThreshold, err)
+}
@@ -0,0 +1,144 @@
+// Package merkle implements Merkle hash trees with inclusion proofs, using the
+// hash function of a suite.
+//
+// The trees have the shape and the domain separation of RFC 9162: the hash of
+// a leaf is H(0x00 || leaf), the hash of an inner node H(0x01 || left ||
+// right), and a tree of n leaves is split into a complete left subtree of the
+// largest power of two below n leaves and a right subtree of the others. With
+// SHA-256 the roots are those of certificate transparency logs.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed by the package merkle.
+type Suite interface {
+	kyber.HashFactory
+}
+
+// MaxLeaves is the largest number of leaves of a tree.
+const MaxLeaves = 1 << 32
+
+const (
+	leafPrefix = 0
+	nodePrefix = 1
+)
+
+// MerkleTree is a Merkle tree over a fixed list of leaves.
+type MerkleTree struct {
+	suite Suite
+	// levels[0] holds the hashes of the leaves and the last level the root.
+	// A node without sibling is moved up to the next level as is.
+	levels [][][]byte
+}
+
+// Proof is the proof that a leaf is the Index-th of a tree of Size leaves.
+type Proof struct {
+	Index uint64
+	Size  uint64
+	// Path gives the hashes of the siblings of the nodes on the way from the
+	// leaf to the root.
+	Path [][]byte
+}
+
+var errInvalidProof = errors.New("merkle: invalid proof")
+
+// NewTree builds the Merkle tree of the leaves.
+func NewTree(suite Suite, leaves [][]byte) (*MerkleTree, error) {
+	if len(leaves) == 0 {
+		return nil, errors.New("merkle: no leaves")
+	}
+	if uint64(len(leaves)) > MaxLeaves {
+		return nil, errors.New("merkle: too many leaves")
+	}
+	h := suite.Hash()
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = leafHash(h, leaf)
+	}
+	t := &MerkleTree{suite: suite, levels: [][][]byte{level}}
+	for len(level) > 1 {
+		next := make([][]byte, (len(level)+1)/2)
+		for i := range next {
+			if 2*i+1 < len(level) {
+				next[i] = nodeHash(h, level[2*i], level[2*i+1])
+			} else {
+				next[i] = level[2*i]
+			}
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t, nil
+}
+
+// Root returns the root hash of the tree.
+func (t *MerkleTree) Root() []byte {
+	return append([]byte{}, t.levels[len(t.levels)-1][0]...)
+}
+
+// Proof returns the inclusion proof of the leaf at index.
+func (t *MerkleTree) Proof(index int) (*Proof, error) {
+	if index < 0 || index >= len(t.levels[0]) {
+		return nil, errors.New("merkle: leaf index out of range")
+	}
+	p := &Proof{Index: uint64(index), Size: uint64(len(t.levels[0]))}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		if sibling := index ^ 1; sibling < len(level) {
+			p.Path = append(p.Path, append([]byte{}, level[sibling]...))
+		}
+		index >>= 1
+	}
+	return p, nil
+}
+
+// Verify checks that proof shows leaf to be in the tree of the given root.
+func Verify(suite Suite, root []byte, leaf []byte, proof *Proof) error {
+	if proof == nil || proof.Index >= proof.Size || proof.Size > MaxLeaves {
+		return errInvalidProof
+	}
+	h := suite.Hash()
+	// the algorithm of RFC 9162 section 2.1.3.2
+	fn, sn := proof.Index, proof.Size-1
+	r := leafHash(h, leaf)
+	for _, p := range proof.Path {
+		if len(p) != h.Size() || sn == 0 {
+			return errInvalidProof
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(h, p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(h, r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 || !bytes.Equal(r, root) {
+		return errInvalidProof
+	}
+	return nil
+}
+
+func leafHash(h hash.Hash, leaf []byte) []byte {
+	h.Reset()
+	h.Write([]byte{leafPrefix})
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+func nodeHash(h hash.Hash, left, right []byte) []byte {
+	h.Reset()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
@@ -0,0 +1,117 @@
+package merkle
+
+import (
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// the leaves and roots of the test vectors of certificate transparency
+var ctLeaves = []string{"", "00", "10", "2021", "3031", "40414243",
+	"5051525354555657", "606162636465666768696a6b6c6d6e6f"}
+
+var ctRoots = []string{
+	"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+	"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+	"aeb6bcfe274b70a14fb067a5e5578264db0fa9b51af5e0ba159158f329e06e77",
+	"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	"4e3bbb1f7b478dcfe71fb631631519a3bca12c9aefca1612bfce4c13a86264d4",
+	"76e67dadbcdf1e10e1b74ddc608abd2f98dfb16fbce75277b5232a127f2087ef",
+	"ddb89be403809e325750d3d263cd78929c2942b7942a34b77e122c9594a74c8c",
+	"5dc9da79a70659a9ad559cb701ded9a2ab9d823aad2f4960cfe370eff4604328",
+}
+
+func TestMerkleVectors(t *testing.T) {
+	var leaves [][]byte
+	for i, l := range ctLeaves {
+		leaf, err := hex.DecodeString(l)
+		require.Nil(t, err)
+		leaves = append(leaves, leaf)
+		tree, err := NewTree(suite, leaves)
+		require.Nil(t, err)
+		require.Equal(t, ctRoots[i], hex.EncodeToString(tree.Root()))
+	}
+}
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 33; n++ {
+		leaves := make([][]byte, n)
+		for i := range leaves {
+			leaves[i] = []byte{byte(i), byte(n)}
+		}
+		tree, err := NewTree(suite, leaves)
+		require.Nil(t, err)
+		root := tree.Root()
+		for i, leaf := range leaves {
+			proof, err := tree.Proof(i)
+			require.Nil(t, err)
+			require.Nil(t, Verify(suite, root, leaf, proof))
+			require.Error(t, Verify(suite, root, []byte("other leaf"), proof))
+			if n > 1 {
+				other := *proof
+				other.Index = uint64((i + 1) % n)
+				require.Error(t, Verify(suite, root, leaf, &other))
+			}
+		}
+	}
+
+	tree, err := NewTree(suite, [][]byte{{1}, {2}, {3}})
+	require.Nil(t, err)
+	_, err = tree.Proof(3)
+	require.Error(t, err)
+	_, err = NewTree(suite, nil)
+	require.Error(t, err)
+	require.Error(t, Verify(suite, tree.Root(), []byte{1}, nil))
+}
+
+func TestQuickMerkleLeafChange(t *testing.T) {
+	f := func(leaves [][]byte, index uint, b byte) bool {
+		if len(leaves) == 0 {
+			return true
+		}
+		tree, err := NewTree(suite, leaves)
+		if err != nil {
+			return false
+		}
+		i := int(index % uint(len(leaves)))
+		changed := append([][]byte{}, leaves...)
+		changed[i] = append(append([]byte{}, leaves[i]...), b)
+		other, err := NewTree(suite, changed)
+		if err != nil {
+			return false
+		}
+		return string(tree.Root()) != string(other.Root())
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickMerkleTamperedProof(t *testing.T) {
+	f := func(leaves [][]byte, index uint, pos uint, bit uint8) bool {
+		if len(leaves) < 2 {
+			return true
+		}
+		tree, err := NewTree(suite, leaves)
+		if err != nil {
+			return false
+		}
+		i := int(index % uint(len(leaves)))
+		proof, err := tree.Proof(i)
+		if err != nil || Verify(suite, tree.Root(), leaves[i], proof) != nil {
+			return false
+		}
+		// flip one bit of one hash of the path
+		h := proof.Path[pos%uint(len(proof.Path))]
+		h[(pos/8)%uint(len(h))] ^= 1 << (bit % 8)
+		return Verify(suite, tree.Root(), leaves[i], proof) != nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
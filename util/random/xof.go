@@ -0,0 +1,48 @@
+package random
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed to build a reader from
+// an XOF.
+type Suite interface {
+	kyber.XOFFactory
+}
+
+// entropyLen is the number of bytes of system entropy seeding the readers of
+// NewXOFReaderFromEntropy.
+const entropyLen = 32
+
+type xofReader struct {
+	sync.Mutex
+	xof kyber.XOF
+}
+
+// NewXOFReader returns an io.Reader producing the output of the XOF of the
+// suite seeded with seed. The output is deterministic, so a seed not known to
+// an adversary must be used when it should be random. The reader can be used
+// in multiple threads.
+func NewXOFReader(suite Suite, seed []byte) io.Reader {
+	return &xofReader{xof: suite.XOF(seed)}
+}
+
+// NewXOFReaderFromEntropy returns an io.Reader like NewXOFReader, seeded with
+// entropy from Go's crypto/rand package.
+func NewXOFReaderFromEntropy(suite Suite) (io.Reader, error) {
+	seed := make([]byte, entropyLen)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return nil, err
+	}
+	return NewXOFReader(suite, seed), nil
+}
+
+func (r *xofReader) Read(b []byte) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+	return r.xof.Read(b)
+}
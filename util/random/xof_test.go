@@ -0,0 +1,96 @@
+package random
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/xof/blake2xb"
+	"github.com/stretchr/testify/require"
+)
+
+// the groups import this package, so the tests use an XOF directly
+type blakeSuite struct{}
+
+func (b *blakeSuite) XOF(seed []byte) kyber.XOF { return blake2xb.New(seed) }
+
+var suite = &blakeSuite{}
+
+func TestXOFReader(t *testing.T) {
+	a := make([]byte, 100)
+	b := make([]byte, 100)
+	_, err := io.ReadFull(NewXOFReader(suite, []byte("seed")), a)
+	require.Nil(t, err)
+	_, err = io.ReadFull(NewXOFReader(suite, []byte("seed")), b)
+	require.Nil(t, err)
+	require.Equal(t, a, b)
+
+	r, err := NewXOFReaderFromEntropy(suite)
+	require.Nil(t, err)
+	_, err = io.ReadFull(r, b)
+	require.Nil(t, err)
+	require.False(t, bytes.Equal(a, b))
+}
+
+func TestXOFReaderConcurrent(t *testing.T) {
+	r, err := NewXOFReaderFromEntropy(suite)
+	require.Nil(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b := make([]byte, 1000)
+			for j := 0; j < 100; j++ {
+				r.Read(b)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestXOFReaderChiSquare checks that the byte values of 1MB of output are
+// uniformly distributed. The reader is seeded with a fixed key, so that the
+// test is deterministic.
+func TestXOFReaderChiSquare(t *testing.T) {
+	buf := make([]byte, 1<<20)
+	_, err := io.ReadFull(NewXOFReader(suite, []byte("chi-square test")), buf)
+	require.Nil(t, err)
+
+	var counts [256]float64
+	for _, b := range buf {
+		counts[b]++
+	}
+	expected := float64(len(buf)) / 256
+	chi2 := 0.0
+	for _, c := range counts {
+		chi2 += (c - expected) * (c - expected) / expected
+	}
+	// with 255 degrees of freedom, the statistic of uniform bytes is larger
+	// than 350 with a probability below 1e-4
+	t.Logf("chi-square statistic %f", chi2)
+	require.True(t, chi2 < 350, "chi-square statistic %f", chi2)
+}
+
+func benchmarkReader(b *testing.B, r io.Reader) {
+	buf := make([]byte, 4096)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		io.ReadFull(r, buf)
+	}
+}
+
+func BenchmarkXOFReader(b *testing.B) {
+	r, err := NewXOFReaderFromEntropy(suite)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkReader(b, r)
+}
+
+func BenchmarkCryptoRand(b *testing.B) {
+	benchmarkReader(b, rand.Reader)
+}
@@ -0,0 +1,87 @@
+// Package replay implements the anti-replay window of IPsec (RFC 4303
+// section 3.4.3), which rejects the messages whose sequence number was already
+// accepted while tolerating out-of-order delivery within the window.
+package replay
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultWindowSize is the number of sequence numbers tracked below the
+// highest one accepted, when NewReplayGuard is given a size of zero.
+const DefaultWindowSize = 64
+
+var (
+	// ErrReplay is returned for a sequence number that was already accepted.
+	ErrReplay = errors.New("replay: sequence number already seen")
+	// ErrStale is returned for a sequence number too old to be tracked by the
+	// window, which cannot be told apart from a replay.
+	ErrStale = errors.New("replay: sequence number outside of the window")
+)
+
+// ReplayGuard tracks the sequence numbers accepted on a channel. It is safe
+// for concurrent use.
+type ReplayGuard struct {
+	lock    sync.Mutex
+	size    uint64
+	top     uint64
+	started bool
+	seen    []uint64 // bitmap of the window, indexed by seq modulo size
+}
+
+// NewReplayGuard returns a guard with a window of the given size.
+func NewReplayGuard(windowSize int) *ReplayGuard {
+	if windowSize <= 0 {
+		windowSize = DefaultWindowSize
+	}
+	return &ReplayGuard{
+		size: uint64(windowSize),
+		seen: make([]uint64, (windowSize+63)/64),
+	}
+}
+
+// Check accepts the sequence number seq and returns nil if it was not seen
+// before and is within the window, and ErrReplay or ErrStale otherwise. A
+// message must only be checked once it is authenticated, otherwise an
+// attacker could advance the window with forged sequence numbers.
+func (g *ReplayGuard) Check(seq uint64) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	switch {
+	case !g.started || seq > g.top:
+		// slide the window, forgetting the numbers that fall out of it
+		if !g.started || seq-g.top >= g.size {
+			for i := range g.seen {
+				g.seen[i] = 0
+			}
+		} else {
+			for s := g.top + 1; s < seq; s++ {
+				g.clear(s)
+			}
+		}
+		g.started = true
+		g.top = seq
+	case g.top-seq >= g.size:
+		return ErrStale
+	case g.isSet(seq):
+		return ErrReplay
+	}
+	g.set(seq)
+	return nil
+}
+
+func (g *ReplayGuard) isSet(seq uint64) bool {
+	i := seq % g.size
+	return g.seen[i/64]&(1<<(i%64)) != 0
+}
+
+func (g *ReplayGuard) set(seq uint64) {
+	i := seq % g.size
+	g.seen[i/64] |= 1 << (i % 64)
+}
+
+func (g *ReplayGuard) clear(seq uint64) {
+	i := seq % g.size
+	g.seen[i/64] &^= 1 << (i % 64)
+}
@@ -0,0 +1,60 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayGuard(t *testing.T) {
+	g := NewReplayGuard(0)
+	for seq := uint64(1); seq <= 5; seq++ {
+		require.Nil(t, g.Check(seq))
+	}
+	require.Equal(t, ErrReplay, g.Check(5))
+
+	// out-of-order delivery within the window
+	require.Nil(t, g.Check(10))
+	require.Nil(t, g.Check(8))
+	require.Nil(t, g.Check(6))
+	require.Equal(t, ErrReplay, g.Check(8))
+	require.Nil(t, g.Check(9))
+	require.Nil(t, g.Check(7))
+	require.Equal(t, ErrReplay, g.Check(3))
+
+	// numbers falling out of the window
+	require.Nil(t, g.Check(10+DefaultWindowSize))
+	require.Equal(t, ErrStale, g.Check(10))
+	require.Nil(t, g.Check(11))
+	require.Equal(t, ErrReplay, g.Check(11))
+
+	// a jump beyond the window forgets everything
+	require.Nil(t, g.Check(1000))
+	require.Nil(t, g.Check(1000-DefaultWindowSize+1))
+	require.Equal(t, ErrStale, g.Check(1000-DefaultWindowSize))
+}
+
+func TestReplayGuardSizes(t *testing.T) {
+	for _, size := range []int{1, 3, 64, 100, 200} {
+		g := NewReplayGuard(size)
+		accepted := make(map[uint64]bool)
+		// deliver 0..999 with each block of size numbers reversed
+		var top uint64
+		for base := 0; base < 1000; base += size {
+			for i := size - 1; i >= 0; i-- {
+				seq := uint64(base + i)
+				err := g.Check(seq)
+				if seq > top || top-seq < uint64(size) {
+					require.Nil(t, err, "size %d seq %d", size, seq)
+					accepted[seq] = true
+				}
+				if seq > top {
+					top = seq
+				}
+			}
+		}
+		for seq := range accepted {
+			require.Error(t, g.Check(seq), "size %d seq %d", size, seq)
+		}
+	}
+}
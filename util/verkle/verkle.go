@@ -0,0 +1,224 @@
+// Package verkle implements the vector commitment of Verkle trees: a
+// Pedersen commitment to a vector of scalars, opened at one index with an
+// inner product argument (IPA) in the style of Bulletproofs. The proof of an
+// opening holds 2·log2(n) points and one scalar for a vector of n scalars.
+//
+// The commitment is binding as long as no discrete logarithm relation between
+// the generators is known, which is the case for the output of Generators.
+// It is not hiding: the commitments of two equal vectors are equal.
+package verkle
+
+import (
+	"errors"
+	"hash"
+
+	"github.com/dedis/kyber"
+)
+
+// Suite represents the set of functionalities needed by the package verkle.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	kyber.XOFFactory
+}
+
+// IPA is the proof that a commitment opens to a value at an index, made of
+// the points L and R of each round of the argument and of the final folded
+// scalar A.
+type IPA struct {
+	L, R []kyber.Point
+	A    kyber.Scalar
+}
+
+var (
+	errLength  = errors.New("verkle: vector and generators of different lengths")
+	errSize    = errors.New("verkle: vector size is not a power of two")
+	errIndex   = errors.New("verkle: index out of range")
+	errInvalid = errors.New("verkle: invalid proof")
+)
+
+// Generators returns n generators of the group derived from a fixed seed, of
+// which no discrete logarithm relation is known.
+func Generators(suite Suite, n int) []kyber.Point {
+	xof := suite.XOF([]byte("kyber verkle generators"))
+	generators := make([]kyber.Point, n)
+	for i := range generators {
+		generators[i] = suite.Point().Pick(xof)
+	}
+	return generators
+}
+
+// Commit returns the commitment Σ vector[i]·generators[i].
+func Commit(suite Suite, vector []kyber.Scalar, generators []kyber.Point) (kyber.Point, error) {
+	if len(vector) != len(generators) {
+		return nil, errLength
+	}
+	return innerProduct(suite, vector, generators), nil
+}
+
+// Prove returns the proof that the commitment of vector opens to
+// vector[index]. The size of the vector must be a power of two.
+func Prove(suite Suite, index int, vector []kyber.Scalar, generators []kyber.Point) (*IPA, error) {
+	if err := checkSizes(len(vector), len(generators), index); err != nil {
+		return nil, err
+	}
+	C := innerProduct(suite, vector, generators)
+	value := vector[index]
+	h, Q, err := start(suite, C, index, value)
+	if err != nil {
+		return nil, err
+	}
+
+	a := append([]kyber.Scalar{}, vector...)
+	b := unitVector(suite, len(vector), index)
+	G := append([]kyber.Point{}, generators...)
+	proof := new(IPA)
+	for n := len(a); n > 1; n /= 2 {
+		m := n / 2
+		aLo, aHi := a[:m], a[m:]
+		bLo, bHi := b[:m], b[m:]
+		GLo, GHi := G[:m], G[m:]
+
+		// L = <aLo, GHi> + <aLo, bHi>·Q and R = <aHi, GLo> + <aHi, bLo>·Q
+		L := innerProduct(suite, aLo, GHi)
+		L.Add(L, suite.Point().Mul(scalarProduct(suite, aLo, bHi), Q))
+		R := innerProduct(suite, aHi, GLo)
+		R.Add(R, suite.Point().Mul(scalarProduct(suite, aHi, bLo), Q))
+		proof.L = append(proof.L, L)
+		proof.R = append(proof.R, R)
+
+		x, err := challenge(suite, h, L, R)
+		if err != nil {
+			return nil, err
+		}
+		xInv := suite.Scalar().Inv(x)
+		for i := 0; i < m; i++ {
+			// a' = aLo + x·aHi, b' = bLo + x^-1·bHi, G' = GLo + x^-1·GHi
+			aLo[i] = suite.Scalar().Add(aLo[i], suite.Scalar().Mul(x, aHi[i]))
+			bLo[i] = suite.Scalar().Add(bLo[i], suite.Scalar().Mul(xInv, bHi[i]))
+			GLo[i] = suite.Point().Add(GLo[i], suite.Point().Mul(xInv, GHi[i]))
+		}
+		a, b, G = aLo, bLo, GLo
+	}
+	proof.A = a[0]
+	return proof, nil
+}
+
+// Verify checks that proof shows that the commitment, made with the given
+// generators, opens to value at index. It returns an error if the proof is
+// invalid.
+func Verify(suite Suite, commitment kyber.Point, generators []kyber.Point, index int, value kyber.Scalar, proof *IPA) error {
+	if err := checkSizes(len(generators), len(generators), index); err != nil {
+		return err
+	}
+	if commitment == nil || value == nil || proof == nil || proof.A == nil ||
+		len(proof.L) != len(proof.R) || 1<<uint(len(proof.L)) != len(generators) {
+		return errInvalid
+	}
+	for round := range proof.L {
+		if proof.L[round] == nil || proof.R[round] == nil {
+			return errInvalid
+		}
+	}
+	h, Q, err := start(suite, commitment, index, value)
+	if err != nil {
+		return err
+	}
+
+	// P = C + value·Q, and P' = P + x^-1·L + x·R at each round
+	P := suite.Point().Add(commitment, suite.Point().Mul(value, Q))
+	b := unitVector(suite, len(generators), index)
+	G := append([]kyber.Point{}, generators...)
+	for round := range proof.L {
+		L, R := proof.L[round], proof.R[round]
+		x, err := challenge(suite, h, L, R)
+		if err != nil {
+			return err
+		}
+		xInv := suite.Scalar().Inv(x)
+		P.Add(P, suite.Point().Mul(xInv, L))
+		P.Add(P, suite.Point().Mul(x, R))
+
+		m := len(G) / 2
+		for i := 0; i < m; i++ {
+			b[i] = suite.Scalar().Add(b[i], suite.Scalar().Mul(xInv, b[m+i]))
+			G[i] = suite.Point().Add(G[i], suite.Point().Mul(xInv, G[m+i]))
+		}
+		b, G = b[:m], G[:m]
+	}
+
+	// P = A·G + A·b·Q
+	expected := suite.Point().Mul(proof.A, G[0])
+	expected.Add(expected, suite.Point().Mul(suite.Scalar().Mul(proof.A, b[0]), Q))
+	if !P.Equal(expected) {
+		return errInvalid
+	}
+	return nil
+}
+
+func checkSizes(n, generators, index int) error {
+	if n != generators {
+		return errLength
+	}
+	if n == 0 || n&(n-1) != 0 {
+		return errSize
+	}
+	if index < 0 || index >= n {
+		return errIndex
+	}
+	return nil
+}
+
+// start begins the transcript of the argument with the statement and returns
+// it with the point Q binding the inner product, which is derived from the
+// statement so that it depends on the commitment.
+func start(suite Suite, commitment kyber.Point, index int, value kyber.Scalar) (hash.Hash, kyber.Point, error) {
+	h := suite.Hash()
+	h.Write([]byte("kyber verkle ipa"))
+	if _, err := commitment.MarshalTo(h); err != nil {
+		return nil, nil, err
+	}
+	h.Write([]byte{byte(index >> 24), byte(index >> 16), byte(index >> 8), byte(index)})
+	if _, err := value.MarshalTo(h); err != nil {
+		return nil, nil, err
+	}
+	Q := suite.Point().Pick(suite.XOF(h.Sum(nil)))
+	return h, Q, nil
+}
+
+// challenge adds the points of a round to the transcript and returns the
+// challenge of the round.
+func challenge(suite Suite, h hash.Hash, L, R kyber.Point) (kyber.Scalar, error) {
+	if _, err := L.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	if _, err := R.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	return suite.Scalar().Pick(suite.XOF(h.Sum(nil))), nil
+}
+
+func innerProduct(suite Suite, a []kyber.Scalar, G []kyber.Point) kyber.Point {
+	P := suite.Point().Null()
+	for i := range a {
+		P.Add(P, suite.Point().Mul(a[i], G[i]))
+	}
+	return P
+}
+
+func scalarProduct(suite Suite, a, b []kyber.Scalar) kyber.Scalar {
+	s := suite.Scalar().Zero()
+	for i := range a {
+		s.Add(s, suite.Scalar().Mul(a[i], b[i]))
+	}
+	return s
+}
+
+func unitVector(suite Suite, n, index int) []kyber.Scalar {
+	b := make([]kyber.Scalar, n)
+	for i := range b {
+		b[i] = suite.Scalar().Zero()
+	}
+	b[index] = suite.Scalar().One()
+	return b
+}
@@ -0,0 +1,126 @@
+package verkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func randomVector(n int) []kyber.Scalar {
+	v := make([]kyber.Scalar, n)
+	for i := range v {
+		v[i] = suite.Scalar().Pick(suite.RandomStream())
+	}
+	return v
+}
+
+func TestVerkle(t *testing.T) {
+	for _, n := range []int{1, 2, 16} {
+		vector := randomVector(n)
+		generators := Generators(suite, n)
+		C, err := Commit(suite, vector, generators)
+		require.Nil(t, err)
+		for i := range vector {
+			proof, err := Prove(suite, i, vector, generators)
+			require.Nil(t, err)
+			require.Len(t, proof.L, len(proof.R))
+			require.Equal(t, 1<<uint(len(proof.L)), n)
+			require.Nil(t, Verify(suite, C, generators, i, vector[i], proof))
+
+			// a wrong value or index fails
+			wrong := suite.Scalar().Add(vector[i], suite.Scalar().One())
+			require.Equal(t, errInvalid, Verify(suite, C, generators, i, wrong, proof))
+			if n > 1 {
+				j := (i + 1) % n
+				require.Equal(t, errInvalid, Verify(suite, C, generators, j, vector[i], proof))
+			}
+		}
+	}
+}
+
+func TestVerkleInvalid(t *testing.T) {
+	vector := randomVector(8)
+	generators := Generators(suite, 8)
+	C, err := Commit(suite, vector, generators)
+	require.Nil(t, err)
+	proof, err := Prove(suite, 3, vector, generators)
+	require.Nil(t, err)
+
+	// another commitment or other generators
+	other, err := Commit(suite, randomVector(8), generators)
+	require.Nil(t, err)
+	require.Equal(t, errInvalid, Verify(suite, other, generators, 3, vector[3], proof))
+	require.Equal(t, errInvalid, Verify(suite, C, randomPoints(8), 3, vector[3], proof))
+
+	// altered proofs
+	altered := &IPA{L: append([]kyber.Point{}, proof.L...), R: proof.R, A: proof.A}
+	altered.L[1] = suite.Point().Add(altered.L[1], suite.Point().Base())
+	require.Equal(t, errInvalid, Verify(suite, C, generators, 3, vector[3], altered))
+	altered = &IPA{L: proof.L, R: proof.R, A: suite.Scalar().Add(proof.A, suite.Scalar().One())}
+	require.Equal(t, errInvalid, Verify(suite, C, generators, 3, vector[3], altered))
+	altered = &IPA{L: proof.L[1:], R: proof.R[1:], A: proof.A}
+	require.Equal(t, errInvalid, Verify(suite, C, generators, 3, vector[3], altered))
+	require.Equal(t, errInvalid, Verify(suite, C, generators, 3, vector[3], nil))
+
+	// missing points
+	for round := range proof.L {
+		altered = &IPA{L: append([]kyber.Point{}, proof.L...), R: proof.R, A: proof.A}
+		altered.L[round] = nil
+		require.Equal(t, errInvalid, Verify(suite, C, generators, 3, vector[3], altered))
+		altered = &IPA{L: proof.L, R: append([]kyber.Point{}, proof.R...), A: proof.A}
+		altered.R[round] = nil
+		require.Equal(t, errInvalid, Verify(suite, C, generators, 3, vector[3], altered))
+	}
+	require.Equal(t, errInvalid, Verify(suite, nil, generators, 3, vector[3], proof))
+	require.Equal(t, errInvalid, Verify(suite, C, generators, 3, nil, proof))
+
+	// bad sizes
+	_, err = Commit(suite, vector, generators[:7])
+	require.Equal(t, errLength, err)
+	_, err = Prove(suite, 0, vector[:6], generators[:6])
+	require.Equal(t, errSize, err)
+	_, err = Prove(suite, 8, vector, generators)
+	require.Equal(t, errIndex, err)
+	_, err = Prove(suite, -1, vector, generators)
+	require.Equal(t, errIndex, err)
+}
+
+func randomPoints(n int) []kyber.Point {
+	points := make([]kyber.Point, n)
+	for i := range points {
+		points[i] = suite.Point().Pick(suite.RandomStream())
+	}
+	return points
+}
+
+func benchmark(b *testing.B, verify bool) {
+	for _, n := range []int{256, 1024} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			vector := randomVector(n)
+			generators := Generators(suite, n)
+			C, err := Commit(suite, vector, generators)
+			require.Nil(b, err)
+			proof, err := Prove(suite, n/3, vector, generators)
+			require.Nil(b, err)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if verify {
+					err = Verify(suite, C, generators, n/3, vector[n/3], proof)
+				} else {
+					_, err = Prove(suite, n/3, vector, generators)
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkProve(b *testing.B)  { benchmark(b, false) }
+func BenchmarkVerify(b *testing.B) { benchmark(b, true) }
@@ -0,0 +1,220 @@
+// Package ecvrf implements the ECVRF-EDWARDS25519-SHA512-ELL2 verifiable
+// random function of RFC 9381.
+//
+// A VRF is the public-key version of a keyed hash: only the owner of the
+// private key can compute the hash beta of an input alpha, but anyone can
+// check with the proof pi that beta is correct for the public key.
+//
+// Proofs use a deterministic nonce derived from the private scalar instead
+// of the seed of RFC 8032, since kyber keys are scalars. They are valid RFC
+// 9381 proofs, beta is the same as for any other implementation, and proofs
+// of other implementations are checked by Verify; but the proof itself
+// differs from the one of an implementation starting from the seed.
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/hashtopoint"
+)
+
+// Suite represents the set of functionalities needed by the package ecvrf.
+// Only the Ed25519 group is supported.
+type Suite interface {
+	kyber.Group
+}
+
+const (
+	suiteString = 0x04
+	ptLen       = 32 // length of an encoded point
+	cLen        = 16 // length of an encoded challenge
+	qLen        = 32 // length of an encoded scalar
+
+	// ProofLen is the length in bytes of a proof pi.
+	ProofLen = ptLen + cLen + qLen
+)
+
+// h2cDST is the domain separation tag of the hash to curve function.
+var h2cDST = []byte("ECVRF_edwards25519_XMD:SHA-512_ELL2_NU_\x04")
+
+// Prove computes the VRF hash beta of alpha under the private key, along with
+// the proof pi that beta is correct.
+func Prove(suite Suite, priv kyber.Scalar, alpha []byte) (beta, pi []byte, err error) {
+	if err := checkSuite(suite); err != nil {
+		return nil, nil, err
+	}
+	Y := suite.Point().Mul(priv, nil)
+	pk, err := Y.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	H, err := hashtopoint.EncodeToPoint(suite, h2cDST, append(pk, alpha...))
+	if err != nil {
+		return nil, nil, err
+	}
+	hString, err := H.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	Gamma := suite.Point().Mul(priv, H)
+
+	// k = SHA512(SHA512(x)[32:] || h_string)
+	x, err := priv.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	prefix := sha512.Sum512(x)
+	k := suite.Scalar().SetBytes(hash(prefix[32:], hString))
+
+	U := suite.Point().Mul(k, nil)
+	V := suite.Point().Mul(k, H)
+	c, err := challenge(suite, Y, H, Gamma, U, V)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := suite.Scalar().Mul(c, priv)
+	s.Add(s, k)
+
+	var buf bytes.Buffer
+	if _, err := Gamma.MarshalTo(&buf); err != nil {
+		return nil, nil, err
+	}
+	cb, err := c.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	buf.Write(cb[:cLen])
+	if _, err := s.MarshalTo(&buf); err != nil {
+		return nil, nil, err
+	}
+	beta, err = proofToHash(suite, Gamma)
+	if err != nil {
+		return nil, nil, err
+	}
+	return beta, buf.Bytes(), nil
+}
+
+// Verify checks the proof pi for alpha under the public key and returns the
+// corresponding VRF hash beta. It returns an error if the proof is invalid.
+func Verify(suite Suite, pub kyber.Point, alpha, pi []byte) (beta []byte, err error) {
+	if err := checkSuite(suite); err != nil {
+		return nil, err
+	}
+	if suite.Point().Mul(suite.Scalar().SetInt64(8), pub).Equal(suite.Point().Null()) {
+		return nil, errors.New("ecvrf: public key of small order")
+	}
+	Gamma, c, s, err := decodeProof(suite, pi)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := pub.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	H, err := hashtopoint.EncodeToPoint(suite, h2cDST, append(pk, alpha...))
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s·B - c·Y and V = s·H - c·Gamma
+	U := suite.Point().Mul(s, nil)
+	U.Sub(U, suite.Point().Mul(c, pub))
+	V := suite.Point().Mul(s, H)
+	V.Sub(V, suite.Point().Mul(c, Gamma))
+	c2, err := challenge(suite, pub, H, Gamma, U, V)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Equal(c2) {
+		return nil, errors.New("ecvrf: invalid proof")
+	}
+	return proofToHash(suite, Gamma)
+}
+
+func checkSuite(suite Suite) error {
+	if suite.String() != "Ed25519" {
+		return errors.New("ecvrf: only the Ed25519 group is supported")
+	}
+	return nil
+}
+
+var order, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+// decodeProof splits pi into Gamma, c and s, checking that the encodings are
+// canonical.
+func decodeProof(suite Suite, pi []byte) (kyber.Point, kyber.Scalar, kyber.Scalar, error) {
+	if len(pi) != ProofLen {
+		return nil, nil, nil, errors.New("ecvrf: invalid proof length")
+	}
+	Gamma, err := decodePoint(suite, pi[:ptLen])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c := suite.Scalar().SetBytes(pi[ptLen : ptLen+cLen])
+	sb := pi[ptLen+cLen:]
+	if new(big.Int).SetBytes(reverse(sb)).Cmp(order) >= 0 {
+		return nil, nil, nil, errors.New("ecvrf: non-canonical scalar")
+	}
+	s := suite.Scalar().SetBytes(sb)
+	return Gamma, c, s, nil
+}
+
+func decodePoint(suite Suite, b []byte) (kyber.Point, error) {
+	P := suite.Point()
+	if err := P.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	check, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(check, b) {
+		return nil, errors.New("ecvrf: non-canonical point")
+	}
+	return P, nil
+}
+
+// challenge computes c = SHA512(suite || 0x02 || Y || H || Gamma || U || V ||
+// 0x00), truncated to cLen bytes.
+func challenge(suite Suite, points ...kyber.Point) (kyber.Scalar, error) {
+	h := sha512.New()
+	h.Write([]byte{suiteString, 0x02})
+	for _, P := range points {
+		if _, err := P.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	h.Write([]byte{0x00})
+	return suite.Scalar().SetBytes(h.Sum(nil)[:cLen]), nil
+}
+
+// proofToHash computes beta = SHA512(suite || 0x03 || 8·Gamma || 0x00).
+func proofToHash(suite Suite, Gamma kyber.Point) ([]byte, error) {
+	h := sha512.New()
+	h.Write([]byte{suiteString, 0x03})
+	if _, err := suite.Point().Mul(suite.Scalar().SetInt64(8), Gamma).MarshalTo(h); err != nil {
+		return nil, err
+	}
+	h.Write([]byte{0x00})
+	return h.Sum(nil), nil
+}
+
+func hash(data ...[]byte) []byte {
+	h := sha512.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,108 @@
+package ecvrf
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/group/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// Examples 13 to 15 of RFC 9381, appendix B.3.
+var vectors = []struct {
+	sk, pk, alpha, pi, beta string
+}{
+	{
+		sk:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		alpha: "",
+		pi:    "7d9c633ffeee27349264cf5c667579fc583b4bda63ab71d001f89c10003ab46f14adf9a3cd8b8412d9038531e865c341cafa73589b023d14311c331a9ad15ff2fb37831e00f0acaa6d73bc9997b06501",
+		beta:  "9d574bf9b8302ec0fc1e21c3ec5368269527b87b462ce36dab2d14ccf80c53cccf6758f058c5b1c856b116388152bbe509ee3b9ecfe63d93c3b4346c1fbc6c54",
+	},
+	{
+		sk:    "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		pk:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+		alpha: "72",
+		pi:    "47b327393ff2dd81336f8a2ef10339112401253b3c714eeda879f12c509072ef055b48372bb82efbdce8e10c8cb9a2f9d60e93908f93df1623ad78a86a028d6bc064dbfc75a6a57379ef855dc6733801",
+		beta:  "38561d6b77b71d30eb97a062168ae12b667ce5c28caccdf76bc88e093e4635987cd96814ce55b4689b3dd2947f80e59aac7b7675f8083865b46c89b2ce9cc735",
+	},
+	{
+		sk:    "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+		pk:    "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+		alpha: "af82",
+		pi:    "926e895d308f5e328e7aa159c06eddbe56d06846abf5d98c2512235eaa57fdce35b46edfc655bc828d44ad09d1150f31374e7ef73027e14760d42e77341fe05467bb286cc2c9d7fde29120a0b2320d04",
+		beta:  "121b7f9b9aaaa29099fc04a94ba52784d44eac976dd1a3cca458733be5cd090a7b5fbd148444f17f8daf1fb55cb04b1ae85a626e30a54b4b0f8abf4a43314a58",
+	},
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.Nil(t, err)
+	return b
+}
+
+// secretScalar derives the private scalar from an RFC 8032 seed.
+func secretScalar(seed []byte) kyber.Scalar {
+	h := sha512.Sum512(seed)
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	return suite.Scalar().SetBytes(h[:32])
+}
+
+func TestVectors(t *testing.T) {
+	for i, v := range vectors {
+		x := secretScalar(unhex(t, v.sk))
+		pub := suite.Point().Mul(x, nil)
+		pk, _ := pub.MarshalBinary()
+		require.Equal(t, v.pk, hex.EncodeToString(pk))
+		alpha := unhex(t, v.alpha)
+
+		beta, err := Verify(suite, pub, alpha, unhex(t, v.pi))
+		require.Nil(t, err, "vector %d", i)
+		require.Equal(t, v.beta, hex.EncodeToString(beta))
+
+		// Only the nonce differs from the proofs of the RFC, so Gamma and
+		// beta are the same.
+		beta, pi, err := Prove(suite, x, alpha)
+		require.Nil(t, err)
+		require.Equal(t, v.beta, hex.EncodeToString(beta))
+		require.Equal(t, v.pi[:2*ptLen], hex.EncodeToString(pi[:ptLen]))
+		beta, err = Verify(suite, pub, alpha, pi)
+		require.Nil(t, err)
+		require.Equal(t, v.beta, hex.EncodeToString(beta))
+	}
+}
+
+func TestMutations(t *testing.T) {
+	x := suite.Scalar().Pick(suite.RandomStream())
+	pub := suite.Point().Mul(x, nil)
+	alpha := []byte("Hello VRF")
+	beta, pi, err := Prove(suite, x, alpha)
+	require.Nil(t, err)
+	b, err := Verify(suite, pub, alpha, pi)
+	require.Nil(t, err)
+	require.Equal(t, beta, b)
+
+	for i := range pi {
+		for _, flip := range []byte{0x01, 0x80, 0xff} {
+			mutated := append([]byte{}, pi...)
+			mutated[i] ^= flip
+			_, err := Verify(suite, pub, alpha, mutated)
+			require.Error(t, err, "byte %d flipped with %x", i, flip)
+		}
+	}
+
+	_, err = Verify(suite, pub, []byte("Hello VRG"), pi)
+	require.Error(t, err)
+	_, err = Verify(suite, suite.Point().Pick(suite.RandomStream()), alpha, pi)
+	require.Error(t, err)
+	_, err = Verify(suite, pub, alpha, pi[:ProofLen-1])
+	require.Error(t, err)
+	_, err = Verify(suite, suite.Point().Null(), alpha, pi)
+	require.Error(t, err)
+}
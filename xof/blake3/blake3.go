@@ -0,0 +1,325 @@
+// Package blake3 provides an implementation of kyber.XOF, and of hash.Hash,
+// based on the BLAKE3 hash function.
+//
+// The implementation is portable Go, without the SIMD code paths that make
+// BLAKE3 fast on large inputs, so it is not necessarily faster than a SHA-2
+// with hardware support. See suites.NewBlake3Suite to use it in a suite.
+package blake3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+
+	"github.com/dedis/kyber"
+)
+
+// Size is the size of a BLAKE3 hash in bytes.
+const Size = 32
+
+const (
+	blockLen = 64
+	chunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var iv = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func g(s *[16]uint32, a, b, c, d int, mx, my uint32) {
+	s[a] += s[b] + mx
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] += s[b] + my
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
+
+// compress is the BLAKE3 compression function, returning the full 16 words of
+// the state.
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, n uint32, flags uint32) [16]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), n, flags,
+	}
+	m := *block
+	for r := 0; r < 7; r++ {
+		g(&s, 0, 4, 8, 12, m[0], m[1])
+		g(&s, 1, 5, 9, 13, m[2], m[3])
+		g(&s, 2, 6, 10, 14, m[4], m[5])
+		g(&s, 3, 7, 11, 15, m[6], m[7])
+		g(&s, 0, 5, 10, 15, m[8], m[9])
+		g(&s, 1, 6, 11, 12, m[10], m[11])
+		g(&s, 2, 7, 8, 13, m[12], m[13])
+		g(&s, 3, 4, 9, 14, m[14], m[15])
+		if r < 6 {
+			var p [16]uint32
+			for i, j := range msgPermutation {
+				p[i] = m[j]
+			}
+			m = p
+		}
+	}
+	for i := 0; i < 8; i++ {
+		s[i] ^= s[i+8]
+		s[i+8] ^= cv[i]
+	}
+	return s
+}
+
+func words(b []byte) (w [16]uint32) {
+	var block [blockLen]byte
+	copy(block[:], b)
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+	return w
+}
+
+// output is the input of the last compression of a node, from which either
+// its chaining value or, for the root, any amount of output can be computed.
+type output struct {
+	cv      [8]uint32
+	block   [16]uint32
+	counter uint64
+	n       uint32
+	flags   uint32
+}
+
+func (o *output) chainingValue() (cv [8]uint32) {
+	s := compress(&o.cv, &o.block, o.counter, o.n, o.flags)
+	copy(cv[:], s[:8])
+	return cv
+}
+
+// rootBlock writes the 64 bytes of output of the root at position t.
+func (o *output) rootBlock(t uint64, dst []byte) {
+	s := compress(&o.cv, &o.block, t, o.n, o.flags|flagRoot)
+	for i, w := range s {
+		binary.LittleEndian.PutUint32(dst[4*i:], w)
+	}
+}
+
+func parentOutput(left, right [8]uint32) *output {
+	o := &output{cv: iv, n: blockLen, flags: flagParent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+// chunk is the state of the compression of the current chunk.
+type chunk struct {
+	cv      [8]uint32
+	counter uint64
+	block   [blockLen]byte
+	n       int
+	blocks  int
+}
+
+func newChunk(counter uint64) chunk {
+	return chunk{cv: iv, counter: counter}
+}
+
+func (c *chunk) len() int {
+	return c.blocks*blockLen + c.n
+}
+
+func (c *chunk) flags() uint32 {
+	if c.blocks == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+func (c *chunk) write(p []byte) {
+	for len(p) > 0 {
+		// a block is only compressed once more input follows, as the last
+		// block of the chunk gets other flags
+		if c.n == blockLen {
+			w := words(c.block[:])
+			s := compress(&c.cv, &w, c.counter, blockLen, c.flags())
+			copy(c.cv[:], s[:8])
+			c.blocks++
+			c.n = 0
+		}
+		k := copy(c.block[c.n:], p)
+		c.n += k
+		p = p[k:]
+	}
+}
+
+func (c *chunk) output() *output {
+	return &output{
+		cv:      c.cv,
+		block:   words(c.block[:c.n]),
+		counter: c.counter,
+		n:       uint32(c.n),
+		flags:   c.flags() | flagChunkEnd,
+	}
+}
+
+// hasher is an incremental BLAKE3 hasher.
+type hasher struct {
+	chunk chunk
+	// stack holds the chaining values of the complete subtrees on the left
+	// of the current chunk.
+	stack [][8]uint32
+}
+
+func (h *hasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if h.chunk.len() == chunkLen {
+			h.pushChunk()
+		}
+		k := chunkLen - h.chunk.len()
+		if k > len(p) {
+			k = len(p)
+		}
+		h.chunk.write(p[:k])
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// pushChunk adds the chaining value of the full current chunk to the tree,
+// merging the subtrees it completes, and starts the next chunk.
+func (h *hasher) pushChunk() {
+	cv := h.chunk.output().chainingValue()
+	total := h.chunk.counter + 1
+	for total&1 == 0 {
+		cv = parentOutput(h.stack[len(h.stack)-1], cv).chainingValue()
+		h.stack = h.stack[:len(h.stack)-1]
+		total >>= 1
+	}
+	h.stack = append(h.stack, cv)
+	h.chunk = newChunk(h.chunk.counter + 1)
+}
+
+// root returns the output of the root node of the input written so far.
+func (h *hasher) root() *output {
+	o := h.chunk.output()
+	for i := len(h.stack) - 1; i >= 0; i-- {
+		o = parentOutput(h.stack[i], o.chainingValue())
+	}
+	return o
+}
+
+func (h *hasher) Sum(b []byte) []byte {
+	var block [blockLen]byte
+	h.root().rootBlock(0, block[:])
+	return append(b, block[:Size]...)
+}
+
+func (h *hasher) Reset() {
+	h.chunk = newChunk(0)
+	h.stack = h.stack[:0]
+}
+
+func (h *hasher) Size() int {
+	return Size
+}
+
+func (h *hasher) BlockSize() int {
+	return blockLen
+}
+
+func (h *hasher) clone() *hasher {
+	return &hasher{chunk: h.chunk, stack: append([][8]uint32{}, h.stack...)}
+}
+
+// NewHash returns a hash.Hash computing 256-bit BLAKE3 hashes.
+func NewHash() hash.Hash {
+	return &hasher{chunk: newChunk(0)}
+}
+
+type xof struct {
+	h *hasher
+	// set when reading starts
+	out *output
+	// buf holds the current block of output, of which pos bytes were read
+	buf     [blockLen]byte
+	pos     int
+	counter uint64
+	// key is here to not make excess garbage during repeated calls
+	// to XORKeyStream.
+	key []byte
+}
+
+// New creates a new XOF using BLAKE3, absorbing seed.
+func New(seed []byte) kyber.XOF {
+	x := &xof{h: &hasher{chunk: newChunk(0)}}
+	x.h.Write(seed)
+	return x
+}
+
+func (x *xof) Clone() kyber.XOF {
+	y := *x
+	y.h = x.h.clone()
+	y.key = nil
+	return &y
+}
+
+func (x *xof) Write(src []byte) (int, error) {
+	if x.out != nil {
+		panic("blake3: write after read")
+	}
+	return x.h.Write(src)
+}
+
+func (x *xof) Read(dst []byte) (int, error) {
+	if x.out == nil {
+		x.out = x.h.root()
+		x.pos = blockLen
+	}
+	n := len(dst)
+	for len(dst) > 0 {
+		if x.pos == blockLen {
+			x.out.rootBlock(x.counter, x.buf[:])
+			x.counter++
+			x.pos = 0
+		}
+		k := copy(dst, x.buf[x.pos:])
+		x.pos += k
+		dst = dst[k:]
+	}
+	return n, nil
+}
+
+func (x *xof) Reseed() {
+	// Use New to create a new one seeded with output from the old one.
+	if len(x.key) < 128 {
+		x.key = make([]byte, 128)
+	} else {
+		x.key = x.key[0:128]
+	}
+	x.Read(x.key)
+	y := New(x.key).(*xof)
+	x.h, x.out, x.pos, x.counter = y.h, nil, 0, 0
+}
+
+func (x *xof) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("dst too short")
+	}
+	if len(x.key) < len(src) {
+		x.key = make([]byte, len(src))
+	} else {
+		x.key = x.key[0:len(src)]
+	}
+	x.Read(x.key)
+	for i, v := range src {
+		dst[i] = v ^ x.key[i]
+	}
+}
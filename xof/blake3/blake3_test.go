@@ -0,0 +1,70 @@
+package blake3
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// input returns the input of the official test vectors, the bytes 0 to 250 in
+// a loop.
+func input(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+var vectors = []struct {
+	in  []byte
+	out string
+}{
+	{[]byte{}, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+	{[]byte("abc"), "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85"},
+	{input(1), "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+	{input(1024), "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+	{input(1025), "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+	{input(2048), "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+}
+
+func TestBlake3Vectors(t *testing.T) {
+	for _, v := range vectors {
+		h := NewHash()
+		h.Write(v.in)
+		require.Equal(t, v.out, hex.EncodeToString(h.Sum(nil)), "length %d", len(v.in))
+
+		// the output of the XOF starts with the hash
+		out := make([]byte, Size)
+		New(v.in).Read(out)
+		require.Equal(t, v.out, hex.EncodeToString(out))
+	}
+}
+
+func TestBlake3Incremental(t *testing.T) {
+	in := input(10000)
+	h := NewHash()
+	h.Write(in)
+	want := h.Sum(nil)
+
+	for _, step := range []int{1, 63, 64, 65, 1023, 1024, 1025, 3000} {
+		h.Reset()
+		for i := 0; i < len(in); i += step {
+			h.Write(in[i:min(i+step, len(in))])
+			// Sum does not change the state
+			h.Sum(nil)
+		}
+		require.Equal(t, want, h.Sum(nil), "step %d", step)
+	}
+
+	// reading the XOF output in pieces gives the same stream
+	full := make([]byte, 300)
+	New(in).Read(full)
+	x := New(in)
+	pieces := make([]byte, 300)
+	for i := 0; i < len(pieces); i += 7 {
+		x.Read(pieces[i:min(i+7, len(pieces))])
+	}
+	require.Equal(t, full, pieces)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dedis/kyber"
 	"github.com/dedis/kyber/xof/blake2xb"
+	"github.com/dedis/kyber/xof/blake3"
 	"github.com/dedis/kyber/xof/keccak"
 	"github.com/stretchr/testify/require"
 )
@@ -19,7 +20,11 @@ type keccakF struct{}
 
 func (b *keccakF) XOF(seed []byte) kyber.XOF { return keccak.New(seed) }
 
-var impls = []kyber.XOFFactory{&blakeF{}, &keccakF{}}
+type blake3F struct{}
+
+func (b *blake3F) XOF(seed []byte) kyber.XOF { return blake3.New(seed) }
+
+var impls = []kyber.XOFFactory{&blakeF{}, &keccakF{}, &blake3F{}}
 
 func TestEncDec(t *testing.T) {
 	lengths := []int{0, 1, 16, 1024, 8192}